@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func nicknameQuery(nickname string) url.Values {
+	return url.Values{"nickname": {nickname}}
+}
+
+func (c *Client) Register(ctx context.Context, username, password string) (Account, error) {
+	var account Account
+	_, err := c.do(ctx, http.MethodPost, "/register", nil, map[string]string{"username": username, "password": password}, &account)
+	return account, err
+}
+
+// Login authenticates the client, which then sends the access token with
+// every request.
+func (c *Client) Login(ctx context.Context, username, password string) error {
+	var tokens TokenPair
+	if _, err := c.do(ctx, http.MethodPost, "/login", nil, map[string]string{"username": username, "password": password}, &tokens); err != nil {
+		return err
+	}
+	c.AccessToken, c.RefreshToken = tokens.AccessToken, tokens.RefreshToken
+	return nil
+}
+
+// Refresh replaces the tokens of the client with new ones. The tokens are
+// kept when the refresh fails.
+func (c *Client) Refresh(ctx context.Context) error {
+	var tokens TokenPair
+	if _, err := c.do(ctx, http.MethodPost, "/token/refresh", nil, map[string]string{"refresh_token": c.RefreshToken}, &tokens); err != nil {
+		return err
+	}
+	c.AccessToken, c.RefreshToken = tokens.AccessToken, tokens.RefreshToken
+	return nil
+}
+
+func (c *Client) Logout(ctx context.Context) error {
+	_, err := c.do(ctx, http.MethodPost, "/logout", nil, map[string]string{"refresh_token": c.RefreshToken}, nil)
+	c.AccessToken, c.RefreshToken = "", ""
+	return err
+}
+
+func (c *Client) CreatePlayer(ctx context.Context, player Player) (Player, error) {
+	var created Player
+	_, err := c.do(ctx, http.MethodPost, "/player", nil, player, &created)
+	return created, err
+}
+
+// ListPlayers returns a page of players and the total number of players
+// matching the filters.
+func (c *Client) ListPlayers(ctx context.Context, options *ListOptions) ([]Player, int, error) {
+	var players []Player
+	total, err := c.do(ctx, http.MethodGet, "/player", options.query(), nil, &players)
+	return players, total, err
+}
+
+func (c *Client) GetPlayer(ctx context.Context, nickname string) (Player, error) {
+	var player Player
+	_, err := c.do(ctx, http.MethodGet, "/player/", nicknameQuery(nickname), nil, &player)
+	return player, err
+}
+
+func (c *Client) UpdatePlayer(ctx context.Context, nickname string, player Player) (Player, error) {
+	var updated Player
+	_, err := c.do(ctx, http.MethodPut, "/player/", nicknameQuery(nickname), player, &updated)
+	return updated, err
+}
+
+func (c *Client) DeletePlayer(ctx context.Context, nickname string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/player/", nicknameQuery(nickname), nil, nil)
+	return err
+}
+
+func (c *Client) PlayerBattles(ctx context.Context, nickname string) ([]Battle, error) {
+	var battles []Battle
+	_, err := c.do(ctx, http.MethodGet, "/player/"+url.PathEscape(nickname)+"/battles", nil, nil, &battles)
+	return battles, err
+}
+
+func (c *Client) PlayerStats(ctx context.Context, nickname string) (BattleStats, error) {
+	var stats BattleStats
+	_, err := c.do(ctx, http.MethodGet, "/player/"+url.PathEscape(nickname)+"/stats", nil, nil, &stats)
+	return stats, err
+}
+
+func (c *Client) CreateEnemy(ctx context.Context, enemy Enemy) (Enemy, error) {
+	var created Enemy
+	_, err := c.do(ctx, http.MethodPost, "/enemy", nil, enemy, &created)
+	return created, err
+}
+
+func (c *Client) ListEnemies(ctx context.Context, options *ListOptions) ([]Enemy, int, error) {
+	var enemies []Enemy
+	total, err := c.do(ctx, http.MethodGet, "/enemy", options.query(), nil, &enemies)
+	return enemies, total, err
+}
+
+func (c *Client) GetEnemy(ctx context.Context, nickname string) (Enemy, error) {
+	var enemy Enemy
+	_, err := c.do(ctx, http.MethodGet, "/enemy/", nicknameQuery(nickname), nil, &enemy)
+	return enemy, err
+}
+
+func (c *Client) UpdateEnemy(ctx context.Context, nickname string, enemy Enemy) (Enemy, error) {
+	var updated Enemy
+	_, err := c.do(ctx, http.MethodPut, "/enemy/", nicknameQuery(nickname), enemy, &updated)
+	return updated, err
+}
+
+func (c *Client) DeleteEnemy(ctx context.Context, nickname string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/enemy/", nicknameQuery(nickname), nil, nil)
+	return err
+}
+
+func (c *Client) EnemyBattles(ctx context.Context, nickname string) ([]Battle, error) {
+	var battles []Battle
+	_, err := c.do(ctx, http.MethodGet, "/enemy/"+url.PathEscape(nickname)+"/battles", nil, nil, &battles)
+	return battles, err
+}
+
+func (c *Client) EnemyStats(ctx context.Context, nickname string) (BattleStats, error) {
+	var stats BattleStats
+	_, err := c.do(ctx, http.MethodGet, "/enemy/"+url.PathEscape(nickname)+"/stats", nil, nil, &stats)
+	return stats, err
+}
+
+func (c *Client) CreateItem(ctx context.Context, item Item) (Item, error) {
+	var created Item
+	_, err := c.do(ctx, http.MethodPost, "/item", nil, item, &created)
+	return created, err
+}
+
+func (c *Client) ListItems(ctx context.Context, options *ListOptions) ([]Item, int, error) {
+	var items []Item
+	total, err := c.do(ctx, http.MethodGet, "/item", options.query(), nil, &items)
+	return items, total, err
+}
+
+// Battle plays one round of the battle between the player and the enemy,
+// starting a new battle when they are not fighting yet.
+func (c *Client) Battle(ctx context.Context, player, enemy, action string) (Battle, error) {
+	var battle Battle
+	_, err := c.do(ctx, http.MethodPost, "/battle", nil, map[string]string{"player": player, "enemy": enemy, "action": action}, &battle)
+	return battle, err
+}
+
+func (c *Client) ListBattles(ctx context.Context, options *ListOptions) ([]Battle, int, error) {
+	var battles []Battle
+	total, err := c.do(ctx, http.MethodGet, "/battle", options.query(), nil, &battles)
+	return battles, total, err
+}
+
+func (c *Client) Leaderboard(ctx context.Context, board, window string, limit int) (Leaderboard, error) {
+	var leaderboard Leaderboard
+	query := url.Values{"board": {board}, "window": {window}, "limit": {strconv.Itoa(limit)}}
+	_, err := c.do(ctx, http.MethodGet, "/leaderboard", query, nil, &leaderboard)
+	return leaderboard, err
+}
@@ -0,0 +1,210 @@
+// Package client is a Go client for the go-simple-rpg-api HTTP API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// An APIError is an error response of the API.
+type APIError struct {
+	StatusCode int
+	Message    string
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("rpg api: %d %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is an API error with status 404.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Client calls the API at BaseURL. Requests failing with a network error or
+// a 429, 502, 503 or 504 response are retried up to MaxRetries times with an
+// exponential backoff starting at Backoff. POST requests are sent with an
+// Idempotency-Key to the routes accepting one, so the server processes their
+// retries once; other POST requests are only retried when the server did not
+// process them (429 and 503).
+type Client struct {
+	BaseURL      string
+	HTTPClient   *http.Client
+	MaxRetries   int
+	Backoff      time.Duration
+	AccessToken  string
+	RefreshToken string
+}
+
+func New(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		MaxRetries: 3,
+		Backoff:    100 * time.Millisecond,
+	}
+}
+
+// ListOptions are the pagination, sorting and filters of list endpoints.
+type ListOptions struct {
+	Limit   int
+	Offset  int
+	Sort    string
+	Filters map[string]string
+}
+
+func (o *ListOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		query.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.Sort != "" {
+		query.Set("sort", o.Sort)
+	}
+	for name, value := range o.Filters {
+		query.Set(name, value)
+	}
+	return query
+}
+
+// idempotentPaths are the POST routes accepting an Idempotency-Key.
+var idempotentPaths = map[string]bool{"/player": true, "/enemy": true, "/item": true, "/battle": true}
+
+func newIdempotencyKey() string {
+	return strconv.FormatUint(rand.Uint64(), 36) + strconv.FormatUint(rand.Uint64(), 36)
+}
+
+func retryable(idempotent bool, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+func (c *Client) wait(ctx context.Context, attempt int, retryAfter string) error {
+	delay := c.Backoff << attempt
+	delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body []byte) (*http.Response, error) {
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	idempotent, key := method != http.MethodPost, ""
+	if method == http.MethodPost && idempotentPaths[path] {
+		idempotent, key = true, newIdempotencyKey()
+	}
+	for attempt := 0; ; attempt++ {
+		request, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Content-Type", "application/json")
+		if c.AccessToken != "" {
+			request.Header.Set("Authorization", "Bearer "+c.AccessToken)
+		}
+		if key != "" {
+			request.Header.Set("Idempotency-Key", key)
+		}
+		response, err := c.HTTPClient.Do(request)
+		if err == nil && !retryable(idempotent, response.StatusCode) {
+			return response, nil
+		}
+		if err != nil && (!idempotent || ctx.Err() != nil) {
+			return nil, err
+		}
+		if attempt == c.MaxRetries {
+			return response, err
+		}
+		retryAfter := ""
+		if response != nil {
+			retryAfter = response.Header.Get("Retry-After")
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+		if err := c.wait(ctx, attempt, retryAfter); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// do sends the request, refreshing the tokens once when the access token
+// expired, and decodes the JSON response into out when out is not nil. It
+// returns the value of the X-Total-Count header for list endpoints.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) (int, error) {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return 0, err
+		}
+	}
+	response, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return 0, err
+	}
+	if response.StatusCode == http.StatusUnauthorized && c.RefreshToken != "" && path != "/token/refresh" {
+		response.Body.Close()
+		if err := c.Refresh(ctx); err != nil {
+			return 0, err
+		}
+		if response, err = c.send(ctx, method, path, query, body); err != nil {
+			return 0, err
+		}
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: response.StatusCode, RequestID: response.Header.Get("X-Request-ID")}
+		data, _ := io.ReadAll(response.Body)
+		var message struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &message) == nil && message.Message != "" {
+			apiErr.Message = message.Message
+		} else {
+			apiErr.Message = strings.TrimSpace(string(data))
+		}
+		return 0, apiErr
+	}
+	total, _ := strconv.Atoi(response.Header.Get("X-Total-Count"))
+	if out != nil && response.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+			return 0, fmt.Errorf("rpg api: decode %s %s response: %w", method, path, err)
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRetriesKeepTheIdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"nickname":"Hero"}`))
+	}))
+	defer server.Close()
+	c := New(server.URL)
+	c.Backoff = 0
+
+	if _, err := c.CreatePlayer(context.Background(), Player{Nickname: "Hero"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 || keys[0] == "" || keys[1] != keys[0] || keys[2] != keys[0] {
+		t.Errorf("idempotency keys %q, want the same key sent 3 times", keys)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	for _, tt := range []struct {
+		idempotent bool
+		status     int
+		want       bool
+	}{
+		{false, http.StatusTooManyRequests, true},
+		{false, http.StatusServiceUnavailable, true},
+		{false, http.StatusBadGateway, false},
+		{true, http.StatusBadGateway, true},
+		{true, http.StatusGatewayTimeout, true},
+		{true, http.StatusInternalServerError, false},
+	} {
+		if got := retryable(tt.idempotent, tt.status); got != tt.want {
+			t.Errorf("retryable(%t, %d) = %t, want %t", tt.idempotent, tt.status, got, tt.want)
+		}
+	}
+}
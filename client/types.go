@@ -0,0 +1,102 @@
+package client
+
+import "time"
+
+type Player struct {
+	Nickname string `json:"nickname"`
+	Life     int    `json:"life"`
+	Attack   int    `json:"attack"`
+	Defense  int    `json:"defense"`
+	Speed    int    `json:"speed"`
+	ItemID   string `json:"item_id"`
+	Owner    string `json:"owner,omitempty"`
+}
+
+type Enemy struct {
+	Nickname string `json:"nickname"`
+	Life     int    `json:"life"`
+	Attack   int    `json:"attack"`
+	Defense  int    `json:"defense"`
+	Speed    int    `json:"speed"`
+	ItemID   string `json:"item_id"`
+	Behavior string `json:"behavior"`
+	Template string `json:"template"`
+}
+
+type Item struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	EffectType  string `json:"effect_type"`
+	EffectValue int    `json:"effect_value"`
+}
+
+type Battle struct {
+	ID           string    `json:"id"`
+	SessionID    string    `json:"session_id"`
+	Round        int       `json:"round"`
+	Enemy        string    `json:"enemy"`
+	Player       string    `json:"player"`
+	DiceThrown   int       `json:"dice_thrown"`
+	Action       string    `json:"action"`
+	EnemyAction  string    `json:"enemy_action"`
+	PlayerDamage int       `json:"player_damage"`
+	EnemyDamage  int       `json:"enemy_damage"`
+	Outcome      string    `json:"outcome,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type BattleStats struct {
+	Battles       int     `json:"battles"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	WinRate       float64 `json:"win_rate"`
+	DamageDealt   int     `json:"damage_dealt"`
+	DamageTaken   int     `json:"damage_taken"`
+	AverageDice   float64 `json:"average_dice"`
+	LongestBattle int     `json:"longest_battle"`
+}
+
+type Score struct {
+	Player          string `json:"player"`
+	Wins            int    `json:"wins"`
+	XP              int    `json:"xp"`
+	Level           int    `json:"level"`
+	EnemiesDefeated int    `json:"enemies_defeated"`
+	HighestHit      int    `json:"highest_hit"`
+}
+
+type Leaderboard struct {
+	Board   string  `json:"board"`
+	Window  string  `json:"window"`
+	Entries []Score `json:"entries"`
+}
+
+type Account struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	Banned   bool   `json:"banned"`
+}
+
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// Battle outcomes, set on the last round of a battle.
+const (
+	OutcomePlayerWon  = "player_won"
+	OutcomeEnemyWon   = "enemy_won"
+	OutcomePlayerFled = "player_fled"
+	OutcomeEnemyFled  = "enemy_fled"
+	OutcomeDraw       = "draw"
+)
+
+// Actions a player can take in a battle round.
+const (
+	ActionAttack = "attack"
+	ActionDefend = "defend"
+	ActionHeal   = "heal"
+	ActionFlee   = "flee"
+	ActionWait   = "wait"
+)
@@ -1,10 +1,18 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"math/rand"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 	"github.com/google/uuid"
 )
@@ -14,7 +22,9 @@ type PlayerRequest struct {
 	Life     int    `json:"life"`
 	Attack   int    `json:"attack"`
 	Defense  int    `json:"defense"`
+	Speed    int    `json:"speed"`
 	ItemID   string `json:"item_id"`
+	Owner    string `json:"owner"`
 }
 
 type PlayerResponse struct {
@@ -26,7 +36,10 @@ type Enemy struct {
 	Life     int    `json:"life"`
 	Attack   int    `json:"attack"`
 	Defense  int    `json:"defense"`
+	Speed    int    `json:"speed"`
 	ItemID   string `json:"item_id"`
+	Behavior string `json:"behavior"`
+	Template string `json:"template"`
 }
 
 type Item struct {
@@ -37,10 +50,24 @@ type Item struct {
 }
 
 type Battle struct {
-	ID         string `json:"id"`
-	Enemy      string `json:"enemy"`
-	Player     string `json:"player"`
-	DiceThrown int    `json:"dice_thrown"`
+	ID           string    `json:"id"`
+	SessionID    string    `json:"session_id"`
+	Round        int       `json:"round"`
+	Enemy        string    `json:"enemy"`
+	Player       string    `json:"player"`
+	DiceThrown   int       `json:"dice_thrown"`
+	Action       Action    `json:"action"`
+	EnemyAction  Action    `json:"enemy_action"`
+	PlayerDamage int       `json:"player_damage"`
+	EnemyDamage  int       `json:"enemy_damage"`
+	Outcome      string    `json:"outcome,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type BattleRequest struct {
+	Enemy  string `json:"enemy"`
+	Player string `json:"player"`
+	Action Action `json:"action"`
 }
 
 var players []PlayerRequest
@@ -48,78 +75,154 @@ var enemies []Enemy
 var battles []Battle
 var items []Item
 
-func main() {
-	initializeItems()
+var rng = newRand(time.Now().UnixNano())
 
-	mux := http.NewServeMux()
+// lockedSource is a rand.Source shared by concurrent requests.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
 
-	mux.HandleFunc("/player", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodPost:
-			AddPlayer(w, r)
-		case http.MethodGet:
-			LoadPlayers(w, r)
-		}
-	})
-
-	mux.HandleFunc("/player/", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodDelete:
-			DeletePlayer(w, r)
-		case http.MethodGet:
-			LoadPlayerByNickname(w, r)
-		case http.MethodPut:
-			SavePlayer(w, r)
-		}
-	})
-
-	mux.HandleFunc("/enemy", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodPost:
-			AddEnemy(w, r)
-		case http.MethodGet:
-			LoadEnemies(w, r)
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// newRand returns a generator safe for concurrent use, seeded so tests can
+// replay the same rolls.
+func newRand(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed).(rand.Source64)})
+}
+
+func main() {
+	var err error
+	if config, err = LoadConfig(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
 		}
-	})
-
-	mux.HandleFunc("/enemy/", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodDelete:
-			DeleteEnemy(w, r)
-		case http.MethodGet:
-			LoadEnemyByNickname(w, r)
-		case http.MethodPut:
-			UpdateEnemy(w, r)
+		fmt.Fprintln(os.Stderr, "invalid configuration:", err)
+		os.Exit(2)
+	}
+	logger, _ = NewLogger(config.Server.LogLevel, config.Server.LogFormat)
+	InitRateLimiters(config.Server.RateLimits)
+
+	defaultBalance := DefaultBalance(config.Game)
+	currentBalance.Store(defaultBalance)
+	if config.Game.BalancePath != "" {
+		b, err := LoadBalance(config.Game.BalancePath, defaultBalance)
+		if err != nil {
+			logger.Error("invalid balance file", "error", err)
+			os.Exit(2)
 		}
-	})
-
-	mux.HandleFunc("/battle", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodPost:
-			CreateBattle(w, r)
-		case http.MethodGet:
-			LoadBattles(w, r)
+		currentBalance.Store(b)
+	}
+
+	store := &Store{Path: config.Server.DataPath}
+	loaded, err := store.Load()
+	if err != nil {
+		logger.Error("cannot load game data", "path", store.Path, "error", err)
+		os.Exit(1)
+	}
+	if !loaded {
+		game.Update(initializeItems)
+	}
+	if config.Server.Admin != "" {
+		if err := BootstrapAdmin(context.Background(), config.Server.Admin, config.Server.AdminPassword); err != nil {
+			logger.Error("cannot bootstrap the admin account", "error", err)
+			os.Exit(2)
 		}
-	})
-
-	mux.HandleFunc("/item", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodPost:
-			AddItem(w, r)
-		case http.MethodGet:
-			LoadItems(w, r)
+	}
+
+	mux := NewMux(store)
+
+	server := &http.Server{
+		Addr:              config.Server.Addr,
+		Handler:           Chain(mux, RequestID, AccessLog(mux.ServeMux), Instrument(mux.ServeMux)),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       config.Server.ReadTimeout.Duration,
+		WriteTimeout:      config.Server.WriteTimeout.Duration,
+		IdleTimeout:       config.Server.IdleTimeout.Duration,
+	}
+	server.RegisterOnShutdown(events.Close)
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go store.SaveEvery(watchCtx, config.Server.SaveInterval.Duration)
+	if config.Game.BalancePath != "" {
+		go WatchBalance(watchCtx, config.Game.BalancePath, defaultBalance, config.Game.BalanceInterval.Duration)
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	grpcServer := NewGRPCServer()
+	if config.Server.GRPCAddr != "" {
+		listener, err := net.Listen("tcp", config.Server.GRPCAddr)
+		if err != nil {
+			logger.Error("cannot listen for gRPC", "addr", config.Server.GRPCAddr, "error", err)
+			os.Exit(1)
 		}
-	})
+		go func() {
+			serverErr <- grpcServer.Serve(listener)
+		}()
+		logger.Info("gRPC server is listening", "addr", config.Server.GRPCAddr)
+	}
+	ready.Store(true)
+	logger.Info("Server is listening", "addr", config.Server.Addr)
 
-	fmt.Println("Server is listening on port 8080")
-	http.ListenAndServe(":8080", mux)
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-serverErr:
+		logger.Error("server stopped", "error", err)
+		os.Exit(1)
+	case sig := <-stop:
+		logger.Info("shutting down", "signal", sig.String())
+	}
+
+	// Shutdown waits for the battles in flight before the data is saved.
+	ready.Store(false)
+	ctx, cancel := context.WithTimeout(context.Background(), config.Server.ShutdownTimeout.Duration)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error("shutdown did not complete", "error", err)
+	}
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+	if err := store.Save(); err != nil {
+		logger.Error("cannot save game data", "path", store.Path, "error", err)
+		os.Exit(1)
+	}
 }
 
+// initializeItems replaces the items with those of the configuration. It
+// expects the lock to be held.
 func initializeItems() {
-	items = []Item{
-		{ID: uuid.NewString(), Name: "Espada do Poder", EffectType: "attack", EffectValue: 5},
-		{ID: uuid.NewString(), Name: "Escudo de Aço", EffectType: "defense", EffectValue: 3},
-		{ID: uuid.NewString(), Name: "Amuleto da Vida", EffectType: "life", EffectValue: 10},
+	items = nil
+	for _, item := range config.Game.Items {
+		item.ID = uuid.NewString()
+		items = append(items, item)
 	}
 }
 
@@ -129,81 +232,29 @@ func AddItem(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
-	item.ID = uuid.NewString()
-	items = append(items, item)
+	item, err := game.AddItem(r.Context(), item)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
 	json.NewEncoder(w).Encode(item)
 }
 
 func LoadItems(w http.ResponseWriter, r *http.Request) {
-	json.NewEncoder(w).Encode(items)
-}
-
-func ApplyItemEffects(player *PlayerRequest, enemy *Enemy) {
-	for _, item := range items {
-		if player.ItemID == item.ID {
-			if item.EffectType == "attack" {
-				player.Attack += item.EffectValue
-			} else if item.EffectType == "defense" {
-				player.Defense += item.EffectValue
-			} else if item.EffectType == "life" {
-				player.Life += item.EffectValue
-			}
-		}
-		if enemy.ItemID == item.ID {
-			if item.EffectType == "attack" {
-				enemy.Attack += item.EffectValue
-			} else if item.EffectType == "defense" {
-				enemy.Defense += item.EffectValue
-			} else if item.EffectType == "life" {
-				enemy.Life += item.EffectValue
-			}
-		}
-	}
+	WriteList(w, r, itemCollection, game.Items())
 }
 
 func CreateBattle(w http.ResponseWriter, r *http.Request) {
-	var battleRequest struct {
-		Enemy  string `json:"enemy"`
-		Player string `json:"player"`
-	}
+	var battleRequest BattleRequest
 	if err := json.NewDecoder(r.Body).Decode(&battleRequest); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
-	var player *PlayerRequest
-	var enemy *Enemy
-	playerFound, enemyFound := false, false
-	for i := range players {
-		if players[i].Nickname == battleRequest.Player {
-			player = &players[i]
-			playerFound = true
-			break
-		}
-	}
-	for i := range enemies {
-		if enemies[i].Nickname == battleRequest.Enemy {
-			enemy = &enemies[i]
-			enemyFound = true
-			break
-		}
-	}
-	if !playerFound || !enemyFound {
-		http.Error(w, "Player or Enemy not found", http.StatusNotFound)
+	battle, err := game.CreateBattle(r.Context(), battleRequest)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
-	ApplyItemEffects(player, enemy)  // Apply item effects before the battle
-	diceThrown := rand.Intn(6) + 1
-	playerDamage := max(0, player.Attack - enemy.Defense + diceThrown)
-	enemyDamage := max(0, enemy.Attack - player.Defense)
-	player.Life = max(0, player.Life - enemyDamage)
-	enemy.Life = max(0, enemy.Life - playerDamage)
-	battle := Battle{
-		ID:         uuid.NewString(),
-		Enemy:      enemy.Nickname,
-		Player:     player.Nickname,
-		DiceThrown: diceThrown,
-	}
-	battles = append(battles, battle)
 	json.NewEncoder(w).Encode(battle)
 }
 
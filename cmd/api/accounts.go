@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+)
+
+const (
+	RolePlayer     = "player"
+	RoleGameMaster = "game_master"
+	RoleAdmin      = "admin"
+)
+
+type Account struct {
+	Username     string `json:"username"`
+	Role         string `json:"role"`
+	Banned       bool   `json:"banned"`
+	PasswordHash string `json:"-"`
+	Salt         string `json:"-"`
+}
+
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+var accounts []Account
+
+const passwordIterations = 100000
+
+// HashPassword derives a key from the password with PBKDF2-HMAC-SHA256.
+func HashPassword(password string, salt []byte) string {
+	mac := hmac.New(sha256.New, []byte(password))
+	mac.Write(salt)
+	mac.Write(binary.BigEndian.AppendUint32(nil, 1))
+	u := mac.Sum(nil)
+	key := append([]byte(nil), u...)
+	for i := 1; i < passwordIterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+		for j := range key {
+			key[j] ^= u[j]
+		}
+	}
+	return hex.EncodeToString(key)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// accountIndex is the index of the account in accounts, -1 when there is
+// none. It expects the lock to be held.
+func accountIndex(username string) int {
+	return slices.IndexFunc(accounts, func(a Account) bool { return a.Username == username })
+}
+
+// FindAccount returns a copy of the account, nil when there is none.
+func FindAccount(username string) *Account {
+	game.mu.RLock()
+	defer game.mu.RUnlock()
+	if i := accountIndex(username); i >= 0 {
+		account := accounts[i]
+		return &account
+	}
+	return nil
+}
+
+func CanControl(account *Account, player PlayerRequest) bool {
+	return account.Role == RoleAdmin || player.Owner == account.Username
+}
+
+func Register(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var credentials Credentials
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Invalid request body"})
+		return
+	}
+	if credentials.Username == "" || len(credentials.Password) < 8 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Username and a password of at least 8 characters are required"})
+		return
+	}
+	salt := randomHex(16)
+	account := Account{
+		Username:     credentials.Username,
+		Role:         RolePlayer,
+		PasswordHash: HashPassword(credentials.Password, []byte(salt)),
+		Salt:         salt,
+	}
+	exists := false
+	game.Update(func() {
+		if exists = accountIndex(account.Username) >= 0; !exists {
+			accounts = append(accounts, account)
+		}
+	})
+	if exists {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Username already exists"})
+		return
+	}
+	RecordAudit(r.Context(), "register_account", account.Username, nil, account)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(account)
+}
+
+// BootstrapAdmin gives the admin role to the account, creating it with the
+// password when it does not exist yet, so a new server can be administered
+// without trusting whoever registers first.
+func BootstrapAdmin(ctx context.Context, username, password string) error {
+	var before *Account
+	var after Account
+	var err error
+	game.Update(func() {
+		i := accountIndex(username)
+		if i < 0 {
+			if password == "" {
+				err = errors.New("admin account " + username + " does not exist and no admin password is set to create it")
+				return
+			}
+			salt := randomHex(16)
+			accounts = append(accounts, Account{Username: username, PasswordHash: HashPassword(password, []byte(salt)), Salt: salt})
+			i = len(accounts) - 1
+		} else {
+			account := accounts[i]
+			before = &account
+		}
+		accounts[i].Role = RoleAdmin
+		after = accounts[i]
+	})
+	if err != nil || (before != nil && before.Role == RoleAdmin) {
+		return err
+	}
+	if before == nil {
+		RecordAudit(ctx, "register_account", username, nil, after)
+	} else {
+		RecordAudit(ctx, "set_account_role", username, *before, after)
+	}
+	return nil
+}
+
+func Login(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var credentials Credentials
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Invalid request body"})
+		return
+	}
+	account := FindAccount(credentials.Username)
+	if account == nil || subtle.ConstantTimeCompare([]byte(account.PasswordHash), []byte(HashPassword(credentials.Password, []byte(account.Salt)))) != 1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Invalid username or password"})
+		return
+	}
+	if account.Banned {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Account is banned"})
+		return
+	}
+	writeTokens(w, account.Username)
+}
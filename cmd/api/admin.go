@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+)
+
+type EnemyTemplate struct {
+	Name      string `json:"name"`
+	MinLife   int    `json:"min_life"`
+	MaxLife   int    `json:"max_life"`
+	MinAttack int    `json:"min_attack"`
+	MaxAttack int    `json:"max_attack"`
+	Defense   int    `json:"defense"`
+	Behavior  string `json:"behavior"`
+}
+
+// StatsAdjustment changes only the stats it sets.
+type StatsAdjustment struct {
+	Life    *int `json:"life"`
+	Attack  *int `json:"attack"`
+	Defense *int `json:"defense"`
+	Speed   *int `json:"speed"`
+}
+
+type RoleRequest struct {
+	Role string `json:"role"`
+}
+
+var roleRanks = map[string]int{
+	RolePlayer:     1,
+	RoleGameMaster: 2,
+	RoleAdmin:      3,
+}
+
+var enemyTemplates []EnemyTemplate
+
+func HasRole(account *Account, role string) bool {
+	return roleRanks[account.Role] >= roleRanks[role]
+}
+
+// RequireRole authenticates the request and rejects accounts below role.
+func RequireRole(role string, next http.HandlerFunc) http.HandlerFunc {
+	return Authenticated(func(w http.ResponseWriter, r *http.Request) {
+		if !HasRole(CurrentAccount(r), role) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(PlayerResponse{Message: "The " + role + " role is required"})
+			return
+		}
+		next(w, r)
+	})
+}
+
+// FindTemplate returns the template with the name, nil when there is none.
+// It expects the lock to be held.
+func FindTemplate(name string) *EnemyTemplate {
+	for i := range enemyTemplates {
+		if enemyTemplates[i].Name == name {
+			return &enemyTemplates[i]
+		}
+	}
+	return nil
+}
+
+func AddEnemyTemplate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var template EnemyTemplate
+	if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Invalid request body"})
+		return
+	}
+	if template.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Enemy template name is required"})
+		return
+	}
+	if template.MinLife < 1 || template.MaxLife < template.MinLife || template.MinAttack < 1 || template.MaxAttack < template.MinAttack {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Enemy template life and attack ranges must start at 1 or more"})
+		return
+	}
+	if _, ok := engine.Behaviors[template.Behavior]; template.Behavior != "" && !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Enemy behavior must be aggressive, defensive, healer, fleeing or random"})
+		return
+	}
+	exists := false
+	game.Update(func() {
+		if exists = FindTemplate(template.Name) != nil; !exists {
+			enemyTemplates = append(enemyTemplates, template)
+		}
+	})
+	if exists {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Enemy template name already exists"})
+		return
+	}
+	RecordAudit(r.Context(), "create_enemy_template", template.Name, nil, template)
+	json.NewEncoder(w).Encode(template)
+}
+
+func LoadEnemyTemplates(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var templates []EnemyTemplate
+	game.View(func() { templates = slices.Clone(enemyTemplates) })
+	json.NewEncoder(w).Encode(templates)
+}
+
+func AdjustPlayerStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var stats StatsAdjustment
+	if err := json.NewDecoder(r.Body).Decode(&stats); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Invalid request body"})
+		return
+	}
+	nickname := r.PathValue("nickname")
+	var before, after PlayerRequest
+	var err error
+	game.Update(func() {
+		i := playerIndex(nickname)
+		if i < 0 {
+			err = gameError(http.StatusNotFound, "Player nickname not found")
+			return
+		}
+		before, after = players[i], players[i]
+		for _, stat := range []struct {
+			value *int
+			field *int
+		}{
+			{stats.Life, &after.Life},
+			{stats.Attack, &after.Attack},
+			{stats.Defense, &after.Defense},
+			{stats.Speed, &after.Speed},
+		} {
+			if stat.value != nil {
+				*stat.field = *stat.value
+			}
+		}
+		if err = validatePlayer(after); err == nil {
+			players[i] = after
+		}
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	RecordAudit(r.Context(), "adjust_player_stats", nickname, before, after)
+	json.NewEncoder(w).Encode(after)
+}
+
+func ResetBattles(w http.ResponseWriter, r *http.Request) {
+	var before map[string]int
+	game.Update(func() {
+		before = map[string]int{"battles": len(battles), "sessions": len(sessions)}
+		battles = nil
+		sessions = nil
+		allTimeLeaderboard = NewLeaderboard()
+		weeklyLeaderboards = map[string]*Leaderboard{}
+	})
+	RecordAudit(r.Context(), "reset_battles", "battles", before, map[string]int{"battles": 0, "sessions": 0})
+	events.Reset()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func SetAccountBan(banned bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var before, account Account
+		found, allowed := false, false
+		game.Update(func() {
+			i := accountIndex(r.PathValue("username"))
+			if found = i >= 0; !found {
+				return
+			}
+			before = accounts[i]
+			if allowed = before.Role == RolePlayer || HasRole(CurrentAccount(r), RoleAdmin); allowed {
+				accounts[i].Banned = banned
+			}
+			account = accounts[i]
+		})
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(PlayerResponse{Message: "Account not found"})
+			return
+		}
+		if !allowed {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(PlayerResponse{Message: "Game masters can only ban player accounts"})
+			return
+		}
+		action := "ban_account"
+		if !banned {
+			action = "unban_account"
+		}
+		RecordAudit(r.Context(), action, account.Username, before, account)
+		json.NewEncoder(w).Encode(account)
+	}
+}
+
+func SetAccountRole(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var request RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Invalid request body"})
+		return
+	}
+	if _, ok := roleRanks[request.Role]; !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Role must be player, game_master or admin"})
+		return
+	}
+	var before, account Account
+	found := false
+	game.Update(func() {
+		i := accountIndex(r.PathValue("username"))
+		if found = i >= 0; !found {
+			return
+		}
+		before = accounts[i]
+		accounts[i].Role = request.Role
+		account = accounts[i]
+	})
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Account not found"})
+		return
+	}
+	RecordAudit(r.Context(), "set_account_role", account.Username, before, account)
+	json.NewEncoder(w).Encode(account)
+}
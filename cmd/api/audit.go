@@ -0,0 +1,104 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// An AuditEntry records one state-changing operation, with the fields that
+// changed on its target. The audit log is append-only.
+type AuditEntry struct {
+	ID        string                 `json:"id"`
+	Actor     string                 `json:"actor"`
+	Action    string                 `json:"action"`
+	Target    string                 `json:"target"`
+	Changes   map[string]AuditChange `json:"changes,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// auditLog has its own lock, since entries are recorded both with and
+// without the game state locked.
+var auditLog []AuditEntry
+var auditMu sync.Mutex
+
+var auditCollection = Collection[AuditEntry]{
+	Filters: map[string]Filter[AuditEntry]{
+		"actor":  EqualFilter(func(e AuditEntry) string { return e.Actor }),
+		"action": EqualFilter(func(e AuditEntry) string { return e.Action }),
+		"target": EqualFilter(func(e AuditEntry) string { return e.Target }),
+		"from":   FromFilter(func(e AuditEntry) time.Time { return e.CreatedAt }),
+		"to":     ToFilter(func(e AuditEntry) time.Time { return e.CreatedAt }),
+	},
+	Sorts: map[string]func(a, b AuditEntry) int{
+		"created_at": func(a, b AuditEntry) int { return a.CreatedAt.Compare(b.CreatedAt) },
+		"actor":      func(a, b AuditEntry) int { return cmp.Compare(a.Actor, b.Actor) },
+		"action":     func(a, b AuditEntry) int { return cmp.Compare(a.Action, b.Action) },
+	},
+}
+
+func auditFields(value any) map[string]any {
+	fields := map[string]any{}
+	if value == nil {
+		return fields
+	}
+	data, _ := json.Marshal(value)
+	json.Unmarshal(data, &fields)
+	return fields
+}
+
+// AuditDiff compares the JSON fields of before and after, either of which is
+// nil when the target is created or deleted.
+func AuditDiff(before, after any) map[string]AuditChange {
+	beforeFields, afterFields := auditFields(before), auditFields(after)
+	changes := map[string]AuditChange{}
+	for name, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[name]) {
+			changes[name] = AuditChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = AuditChange{After: value}
+		}
+	}
+	return changes
+}
+
+func RecordAudit(ctx context.Context, action, target string, before, after any) {
+	actor := "anonymous"
+	if account := AccountFrom(ctx); account != nil {
+		actor = account.Username
+	}
+	entry := AuditEntry{
+		ID:        uuid.NewString(),
+		Actor:     actor,
+		Action:    action,
+		Target:    target,
+		Changes:   AuditDiff(before, after),
+		CreatedAt: time.Now(),
+	}
+	auditMu.Lock()
+	auditLog = append(auditLog, entry)
+	auditMu.Unlock()
+	ContextLogger(ctx).Debug("audit", "actor", actor, "action", action, "target", target)
+}
+
+func LoadAuditLog(w http.ResponseWriter, r *http.Request) {
+	auditMu.Lock()
+	entries := slices.Clone(auditLog)
+	auditMu.Unlock()
+	WriteList(w, r, auditCollection, entries)
+}
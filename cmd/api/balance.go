@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+)
+
+// Balance holds the game balance values designers can change while the
+// server runs. A Balance is never modified once published, reloads swap in a
+// new one.
+type Balance = engine.Balance
+
+var currentBalance atomic.Pointer[Balance]
+
+// DefaultBalance is the engine default balance with the ranges and dice of
+// the game configuration.
+func DefaultBalance(game GameConfig) *Balance {
+	b := engine.DefaultBalance()
+	b.EnemyLife, b.EnemyAttack, b.EnemySpeed = game.EnemyLife, game.EnemyAttack, game.EnemySpeed
+	b.DiceSides = game.DiceSides
+	return b
+}
+
+func CurrentBalance() *Balance {
+	return currentBalance.Load()
+}
+
+// LoadBalance reads a balance file over the defaults, so the file only needs
+// the values it changes.
+func LoadBalance(path string, defaults *Balance) (*Balance, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	b := *defaults
+	b.ItemEffects = map[string]int{}
+	for name, value := range defaults.ItemEffects {
+		b.ItemEffects[name] = value
+	}
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := b.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &b, nil
+}
+
+// WatchBalance polls the balance file and swaps in every valid new version.
+// An invalid file is logged and the previous balance stays live.
+func WatchBalance(ctx context.Context, path string, defaults *Balance, interval time.Duration) {
+	var modified time.Time
+	if info, err := os.Stat(path); err == nil {
+		modified = info.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Equal(modified) {
+			continue
+		}
+		modified = info.ModTime()
+		b, err := LoadBalance(path, defaults)
+		if err != nil {
+			logger.Error("balance file rejected, keeping the previous balance", "path", path, "error", err)
+			continue
+		}
+		currentBalance.Store(b)
+		logger.Info("balance file reloaded", "path", path)
+	}
+}
+
+func LoadCurrentBalance(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(CurrentBalance())
+}
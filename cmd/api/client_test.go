@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Uemerson/go-simple-rpg-api/client"
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+)
+
+// newTestClient starts the API on an httptest server and returns a client
+// logged in as a game master.
+func newTestClient(t *testing.T) *client.Client {
+	t.Helper()
+	currentBalance.Store(engine.DefaultBalance())
+	t.Cleanup(func() {
+		game.Update(func() {
+			players, enemies, battles, sessions, items, accounts = nil, nil, nil, nil, nil, nil
+		})
+	})
+	server := httptest.NewServer(NewMux(&Store{}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	if err := BootstrapAdmin(ctx, "root", "password1"); err != nil {
+		t.Fatal(err)
+	}
+	c := client.New(server.URL)
+	if err := c.Login(ctx, "root", "password1"); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClient(t *testing.T) {
+	c, ctx := newTestClient(t), context.Background()
+
+	if _, err := c.Register(ctx, "alice", "password1"); err != nil {
+		t.Fatal(err)
+	}
+	var apiErr *client.APIError
+	if _, err := c.Register(ctx, "alice", "password1"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Errorf("duplicate registration: %v, want a 409 API error", err)
+	}
+
+	player, err := c.CreatePlayer(ctx, client.Player{Nickname: "Hero", Life: 50, Attack: 5, Defense: 2, Speed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if player.Nickname != "Hero" || player.Owner != "root" {
+		t.Errorf("created player %+v", player)
+	}
+	if _, err := c.CreateEnemy(ctx, client.Enemy{Nickname: "Slime"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.GetPlayer(ctx, "Hero"); err != nil || got.Life != 50 {
+		t.Errorf("GetPlayer: %+v, %v", got, err)
+	}
+	if _, err := c.GetPlayer(ctx, "Nobody"); !client.IsNotFound(err) {
+		t.Errorf("GetPlayer of a missing player: %v, want not found", err)
+	}
+	if list, total, err := c.ListPlayers(ctx, &client.ListOptions{Limit: 1}); err != nil || len(list) != 1 || total != 1 {
+		t.Errorf("ListPlayers: %d players of %d, %v", len(list), total, err)
+	}
+
+	battle, err := c.Battle(ctx, "Hero", "Slime", client.ActionAttack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if battle.Round != 1 || battle.Player != "Hero" || battle.Enemy != "Slime" {
+		t.Errorf("first round %+v", battle)
+	}
+	if history, err := c.PlayerBattles(ctx, "Hero"); err != nil || len(history) != 1 {
+		t.Errorf("PlayerBattles: %d battles, %v", len(history), err)
+	}
+}
+
+func TestClientRefresh(t *testing.T) {
+	c, ctx := newTestClient(t), context.Background()
+
+	// A failed refresh keeps the tokens.
+	access, refresh := c.AccessToken, c.RefreshToken
+	c.RefreshToken = "invalid"
+	if err := c.Refresh(ctx); err == nil {
+		t.Fatal("refresh with an invalid token succeeded")
+	}
+	if c.AccessToken != access {
+		t.Error("access token dropped by a failed refresh")
+	}
+
+	c.RefreshToken = refresh
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if c.RefreshToken == refresh {
+		t.Error("refresh token not replaced")
+	}
+
+	// An expired access token is refreshed once and the request sent again.
+	c.AccessToken = "expired"
+	if _, err := c.CreatePlayer(ctx, client.Player{Nickname: "Hero", Life: 50, Attack: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if c.AccessToken == "expired" {
+		t.Error("access token not refreshed")
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+)
+
+// Duration is a time.Duration written as "10s" in config files.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) Set(value string) (err error) {
+	d.Duration, err = time.ParseDuration(value)
+	return err
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return d.Set(value)
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Range is the engine stat range, written as "1-10" in flags and
+// environment variables.
+type Range = engine.Range
+
+type RateLimits map[string]RateLimit
+
+func (l *RateLimits) String() string {
+	var entries []string
+	for route, limit := range *l {
+		entries = append(entries, route+"="+strconv.FormatFloat(limit.Rate, 'g', -1, 64)+":"+strconv.Itoa(limit.Burst))
+	}
+	slices.Sort(entries)
+	return strings.Join(entries, ",")
+}
+
+func (l *RateLimits) Set(value string) error {
+	limits, err := ParseRateLimits(value)
+	if err != nil {
+		return err
+	}
+	if *l == nil {
+		*l = RateLimits{}
+	}
+	for route, limit := range limits {
+		(*l)[route] = limit
+	}
+	return nil
+}
+
+type ServerConfig struct {
+	Addr                 string     `json:"addr"`
+	GRPCAddr             string     `json:"grpc_addr"`
+	DataPath             string     `json:"data_path"`
+	SaveInterval         Duration   `json:"save_interval"`
+	LogLevel             string     `json:"log_level"`
+	LogFormat            string     `json:"log_format"`
+	ReadTimeout          Duration   `json:"read_timeout"`
+	WriteTimeout         Duration   `json:"write_timeout"`
+	IdleTimeout          Duration   `json:"idle_timeout"`
+	ShutdownTimeout      Duration   `json:"shutdown_timeout"`
+	RateLimits           RateLimits `json:"rate_limits"`
+	GraphQLMaxDepth      int        `json:"graphql_max_depth"`
+	GraphQLMaxComplexity int        `json:"graphql_max_complexity"`
+	IdempotencyWindow    Duration   `json:"idempotency_window"`
+	// Admin is the account given the admin role on startup, created with
+	// AdminPassword when it does not exist yet.
+	Admin         string `json:"admin"`
+	AdminPassword string `json:"admin_password"`
+}
+
+type GameConfig struct {
+	PlayerLife      Range    `json:"player_life"`
+	PlayerAttack    Range    `json:"player_attack"`
+	PlayerDefense   Range    `json:"player_defense"`
+	PlayerSpeed     Range    `json:"player_speed"`
+	EnemyLife       Range    `json:"enemy_life"`
+	EnemyAttack     Range    `json:"enemy_attack"`
+	EnemySpeed      Range    `json:"enemy_speed"`
+	DiceSides       int      `json:"dice_sides"`
+	BattleCooldown  Duration `json:"battle_cooldown"`
+	Items           []Item   `json:"items"`
+	BalancePath     string   `json:"balance_path"`
+	BalanceInterval Duration `json:"balance_interval"`
+}
+
+type Config struct {
+	Server ServerConfig `json:"server"`
+	Game   GameConfig   `json:"game"`
+}
+
+var config = DefaultConfig()
+
+func DefaultConfig() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:            ":8080",
+			GRPCAddr:        ":9090",
+			SaveInterval:    Duration{5 * time.Second},
+			LogLevel:        "info",
+			LogFormat:       "text",
+			ReadTimeout:     Duration{10 * time.Second},
+			WriteTimeout:    Duration{10 * time.Second},
+			IdleTimeout:     Duration{60 * time.Second},
+			ShutdownTimeout: Duration{30 * time.Second},
+			RateLimits: RateLimits{
+				"POST /register": {Rate: 0.1, Burst: 3},
+				"POST /login":    {Rate: 0.2, Burst: 5},
+				"POST /player":   {Rate: 0.5, Burst: 5},
+				"POST /enemy":    {Rate: 0.5, Burst: 5},
+				"POST /item":     {Rate: 0.5, Burst: 5},
+				"POST /battle":   {Rate: 2, Burst: 5},
+			},
+			GraphQLMaxDepth:      6,
+			GraphQLMaxComplexity: 2000,
+			IdempotencyWindow:    Duration{24 * time.Hour},
+		},
+		Game: GameConfig{
+			PlayerLife:      Range{Min: 1, Max: 100},
+			PlayerAttack:    Range{Min: 1, Max: 10},
+			PlayerDefense:   Range{Min: 0, Max: 10},
+			PlayerSpeed:     Range{Min: 0, Max: 10},
+			EnemyLife:       Range{Min: 1, Max: 10},
+			EnemyAttack:     Range{Min: 1, Max: 10},
+			EnemySpeed:      Range{Min: 1, Max: 10},
+			DiceSides:       6,
+			BattleCooldown:  Duration{time.Second},
+			BalanceInterval: Duration{2 * time.Second},
+			Items: []Item{
+				{Name: "Espada do Poder", EffectType: "attack", EffectValue: 5},
+				{Name: "Escudo de Aço", EffectType: "defense", EffectValue: 3},
+				{Name: "Amuleto da Vida", EffectType: "life", EffectValue: 10},
+			},
+		},
+	}
+}
+
+func (c *Config) flagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	fs.String("config", "", "JSON config file")
+	fs.StringVar(&c.Server.Addr, "addr", c.Server.Addr, "address the server listens on")
+	fs.StringVar(&c.Server.GRPCAddr, "grpc-addr", c.Server.GRPCAddr, "address the gRPC server listens on, disabled when empty")
+	fs.StringVar(&c.Server.DataPath, "data", c.Server.DataPath, "JSON file the game data is loaded from and saved to, in memory only when empty")
+	fs.Var(&c.Server.SaveInterval, "save-interval", "how often changed game data is saved to the data file")
+	fs.StringVar(&c.Server.LogLevel, "log-level", c.Server.LogLevel, "log level: debug, info, warn or error")
+	fs.StringVar(&c.Server.LogFormat, "log-format", c.Server.LogFormat, "log format: text or json")
+	fs.Var(&c.Server.ReadTimeout, "read-timeout", "maximum duration for reading a request")
+	fs.Var(&c.Server.WriteTimeout, "write-timeout", "maximum duration for writing a response")
+	fs.Var(&c.Server.IdleTimeout, "idle-timeout", "maximum duration of idle keep-alive connections")
+	fs.Var(&c.Server.ShutdownTimeout, "shutdown-timeout", "maximum duration to wait for requests in flight on shutdown")
+	fs.Var(&c.Server.RateLimits, "rate-limits", "per route rate limits, as \"POST /battle=2:5,POST /enemy=0.5:5\"")
+	fs.IntVar(&c.Server.GraphQLMaxDepth, "graphql-max-depth", c.Server.GraphQLMaxDepth, "maximum nesting of fields in a GraphQL query")
+	fs.Var(&c.Server.IdempotencyWindow, "idempotency-window", "how long responses are replayed for a repeated Idempotency-Key")
+	fs.IntVar(&c.Server.GraphQLMaxComplexity, "graphql-max-complexity", c.Server.GraphQLMaxComplexity, "maximum number of fields a GraphQL query can resolve")
+	fs.StringVar(&c.Server.Admin, "admin", c.Server.Admin, "account given the admin role on startup, created when missing")
+	fs.StringVar(&c.Server.AdminPassword, "admin-password", c.Server.AdminPassword, "password the admin account is created with, preferably set through "+envName("admin-password"))
+	fs.Var(&c.Game.PlayerLife, "player-life", "allowed player life, as min-max")
+	fs.Var(&c.Game.PlayerAttack, "player-attack", "allowed player attack, as min-max")
+	fs.Var(&c.Game.PlayerDefense, "player-defense", "allowed player defense, as min-max")
+	fs.Var(&c.Game.PlayerSpeed, "player-speed", "allowed player speed, as min-max")
+	fs.Var(&c.Game.EnemyLife, "enemy-life", "enemy life rolled when spawning, as min-max")
+	fs.Var(&c.Game.EnemyAttack, "enemy-attack", "enemy attack rolled when spawning, as min-max")
+	fs.Var(&c.Game.EnemySpeed, "enemy-speed", "enemy speed rolled when spawning, as min-max")
+	fs.IntVar(&c.Game.DiceSides, "dice-sides", c.Game.DiceSides, "sides of the dice thrown in battle")
+	fs.Var(&c.Game.BattleCooldown, "battle-cooldown", "minimum duration between two battle rounds of a player")
+	fs.StringVar(&c.Game.BalancePath, "balance", c.Game.BalancePath, "JSON balance file reloaded while the server runs")
+	fs.Var(&c.Game.BalanceInterval, "balance-interval", "how often the balance file is checked for changes")
+	return fs
+}
+
+// envName is the environment variable of a flag, RPG_LOG_LEVEL for -log-level.
+func envName(flagName string) string {
+	return "RPG_" + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// LoadConfig builds the configuration from, in increasing order of
+// precedence, the defaults, the config file, environment variables and
+// command line flags.
+func LoadConfig(args []string) (Config, error) {
+	c := DefaultConfig()
+	fs := c.flagSet()
+	if err := fs.Parse(args); err != nil {
+		return c, err
+	}
+	explicit := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = f.Value.String()
+	})
+
+	path := os.Getenv(envName("config"))
+	if value, ok := explicit["config"]; ok {
+		path = value
+	}
+	c = DefaultConfig()
+	fs = c.flagSet()
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return c, err
+		}
+		defer file.Close()
+		decoder := json.NewDecoder(file)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&c); err != nil {
+			return c, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if value, ok := os.LookupEnv(envName(f.Name)); ok && f.Name != "config" && err == nil {
+			if setErr := f.Value.Set(value); setErr != nil {
+				err = fmt.Errorf("%s: %w", envName(f.Name), setErr)
+			}
+		}
+	})
+	if err != nil {
+		return c, err
+	}
+	for name, value := range explicit {
+		if err := fs.Set(name, value); err != nil {
+			return c, fmt.Errorf("-%s: %w", name, err)
+		}
+	}
+	return c, c.Validate()
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("server address is required"))
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Server.LogLevel)); err != nil {
+		errs = append(errs, fmt.Errorf("log level must be debug, info, warn or error, got %q", c.Server.LogLevel))
+	}
+	if c.Server.LogFormat != "text" && c.Server.LogFormat != "json" {
+		errs = append(errs, fmt.Errorf("log format must be text or json, got %q", c.Server.LogFormat))
+	}
+	for _, d := range []struct {
+		name  string
+		value Duration
+	}{
+		{"read timeout", c.Server.ReadTimeout},
+		{"write timeout", c.Server.WriteTimeout},
+		{"idle timeout", c.Server.IdleTimeout},
+		{"shutdown timeout", c.Server.ShutdownTimeout},
+		{"save interval", c.Server.SaveInterval},
+		{"idempotency window", c.Server.IdempotencyWindow},
+	} {
+		if d.value.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", d.name))
+		}
+	}
+	var routes []string
+	for route := range c.Server.RateLimits {
+		routes = append(routes, route)
+	}
+	slices.Sort(routes)
+	for _, route := range routes {
+		if limit := c.Server.RateLimits[route]; limit.Rate <= 0 || limit.Burst < 1 {
+			errs = append(errs, fmt.Errorf("rate limit of %s must have a positive rate and a burst of at least 1", route))
+		}
+	}
+	if c.Server.AdminPassword != "" && len(c.Server.AdminPassword) < 8 {
+		errs = append(errs, errors.New("admin password must have at least 8 characters"))
+	}
+	if c.Server.GraphQLMaxDepth < 1 || c.Server.GraphQLMaxComplexity < 1 {
+		errs = append(errs, errors.New("GraphQL maximum depth and complexity must be positive"))
+	}
+	// Defense and speed can be 0, the other stats must be at least 1.
+	for _, r := range []struct {
+		name  string
+		value Range
+		min   int
+	}{
+		{"player life", c.Game.PlayerLife, 1},
+		{"player attack", c.Game.PlayerAttack, 1},
+		{"player defense", c.Game.PlayerDefense, 0},
+		{"player speed", c.Game.PlayerSpeed, 0},
+		{"enemy life", c.Game.EnemyLife, 1},
+		{"enemy attack", c.Game.EnemyAttack, 1},
+		{"enemy speed", c.Game.EnemySpeed, 1},
+	} {
+		if r.value.Min < r.min || r.value.Max < r.value.Min {
+			errs = append(errs, fmt.Errorf("%s range must start at %d or more and not end before it starts, got %d-%d", r.name, r.min, r.value.Min, r.value.Max))
+		}
+	}
+	if c.Game.DiceSides < 2 {
+		errs = append(errs, fmt.Errorf("dice must have at least 2 sides, got %d", c.Game.DiceSides))
+	}
+	if c.Game.BalanceInterval.Duration <= 0 {
+		errs = append(errs, errors.New("balance interval must be positive"))
+	}
+	if c.Game.BattleCooldown.Duration < 0 {
+		errs = append(errs, errors.New("battle cooldown cannot be negative"))
+	}
+	for i, item := range c.Game.Items {
+		if item.Name == "" {
+			errs = append(errs, fmt.Errorf("item %d has no name", i+1))
+		}
+		if !engine.ValidEffect(item.EffectType) {
+			errs = append(errs, fmt.Errorf("item %q effect type must be attack, defense or life", item.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
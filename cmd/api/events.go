@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Battle event types.
+const (
+	EventItem  = "item"
+	EventRound = "round"
+	EventDeath = "death"
+	EventEnd   = "end"
+)
+
+const (
+	eventHistory     = 256
+	subscriberBuffer = 64
+	heartbeat        = 15 * time.Second
+)
+
+// An Event is pushed to the streams as battle rounds resolve. Round events
+// carry the battle record with the dice and damage, item events the item
+// equipped by a combatant when the battle started, and death events the
+// combatant that fell.
+type Event struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	SessionID string    `json:"session_id"`
+	Player    string    `json:"player"`
+	Enemy     string    `json:"enemy"`
+	Battle    *Battle   `json:"battle,omitempty"`
+	Item      *Item     `json:"item,omitempty"`
+	Target    string    `json:"target,omitempty"`
+	Outcome   string    `json:"outcome,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type subscriber struct {
+	events chan Event
+	match  func(Event) bool
+}
+
+// EventBroker fans events out to the subscribed streams and keeps the last
+// events so reconnecting clients can resume with Last-Event-ID. A stream
+// that falls too far behind is dropped rather than slowing down battles.
+type EventBroker struct {
+	mu          sync.Mutex
+	nextID      int64
+	history     []Event
+	subscribers map[*subscriber]bool
+	closed      bool
+}
+
+var events = &EventBroker{subscribers: map[*subscriber]bool{}}
+
+func (b *EventBroker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	event.ID = b.nextID
+	event.CreatedAt = time.Now()
+	b.history = append(b.history, event)
+	if len(b.history) > eventHistory {
+		b.history = b.history[len(b.history)-eventHistory:]
+	}
+	for s := range b.subscribers {
+		if !s.match(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			delete(b.subscribers, s)
+			close(s.events)
+		}
+	}
+}
+
+// Subscribe returns the kept events after lastID matching the filter and a
+// channel receiving the next ones. The channel is closed by cancel, when the
+// broker is closed, or when the subscriber falls behind.
+func (b *EventBroker) Subscribe(lastID int64, match func(Event) bool) ([]Event, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var missed []Event
+	for _, event := range b.history {
+		if event.ID > lastID && match(event) {
+			missed = append(missed, event)
+		}
+	}
+	s := &subscriber{events: make(chan Event, subscriberBuffer), match: match}
+	if b.closed {
+		close(s.events)
+		return missed, s.events, func() {}
+	}
+	b.subscribers[s] = true
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if b.subscribers[s] {
+			delete(b.subscribers, s)
+			close(s.events)
+		}
+	}
+	return missed, s.events, cancel
+}
+
+// Close ends every stream, so that shutting down the server does not wait
+// for spectators to disconnect.
+func (b *EventBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for s := range b.subscribers {
+		delete(b.subscribers, s)
+		close(s.events)
+	}
+}
+
+func (b *EventBroker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.history = nil
+}
+
+// PublishRound publishes the events of a resolved round: the equipped items
+// on the first round, the round itself, and the deaths and outcome when it
+// ended the battle. It expects the lock to be held.
+func PublishRound(battle Battle, player PlayerRequest, enemy Enemy) {
+	base := Event{SessionID: battle.SessionID, Player: battle.Player, Enemy: battle.Enemy}
+	if battle.Round == 1 {
+		for i := range items {
+			if items[i].ID == player.ItemID || items[i].ID == enemy.ItemID {
+				event := base
+				event.Type = EventItem
+				item := items[i]
+				event.Item = &item
+				event.Target = battle.Player
+				if items[i].ID != player.ItemID {
+					event.Target = battle.Enemy
+				}
+				events.Publish(event)
+			}
+		}
+	}
+	round := base
+	round.Type = EventRound
+	round.Battle = &battle
+	events.Publish(round)
+	for _, dead := range []struct {
+		name string
+		life int
+	}{{battle.Player, player.Life}, {battle.Enemy, enemy.Life}} {
+		if dead.life <= 0 {
+			event := base
+			event.Type = EventDeath
+			event.Target = dead.name
+			events.Publish(event)
+		}
+	}
+	if battle.Outcome != "" {
+		end := base
+		end.Type = EventEnd
+		end.Outcome = battle.Outcome
+		events.Publish(end)
+	}
+}
+
+// stream writes the events as Server-Sent Events until the client goes
+// away, the broker drops the stream, or until returns true. Without follow
+// it only replays the kept events.
+func stream(w http.ResponseWriter, r *http.Request, follow bool, match func(Event) bool, until func(Event) bool) {
+	controller := http.NewResponseController(w)
+	// The write timeout of the server would cut every stream short.
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+		RequestLogger(r).Warn("cannot disable write deadline for event stream", "error", err)
+	}
+	lastID, _ := strconv.ParseInt(r.Header.Get("Last-Event-ID"), 10, 64)
+	missed, live, cancel := events.Subscribe(lastID, match)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event Event) bool {
+		data, err := json.Marshal(event)
+		if err != nil {
+			RequestLogger(r).Error("cannot encode event", "error", err)
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+			return false
+		}
+		return controller.Flush() == nil
+	}
+	for _, event := range missed {
+		if !send(event) || until(event) {
+			return
+		}
+	}
+	if err := controller.Flush(); err != nil || !follow {
+		return
+	}
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil || controller.Flush() != nil {
+				return
+			}
+		case event, ok := <-live:
+			if !ok || !send(event) || until(event) {
+				return
+			}
+		}
+	}
+}
+
+// StreamBattleEvents streams the events of one battle session and ends the
+// stream with the battle. For a finished battle it replays the events still
+// kept.
+func StreamBattleEvents(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("session")
+	session, ok := game.Session(id)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Battle session not found"})
+		return
+	}
+	stream(w, r, session.Status == SessionActive,
+		func(e Event) bool { return e.SessionID == id },
+		func(e Event) bool { return e.Type == EventEnd })
+}
+
+// StreamEvents streams the events of every battle, optionally only those of
+// a player or an enemy.
+func StreamEvents(w http.ResponseWriter, r *http.Request) {
+	player, enemy := r.URL.Query().Get("player"), r.URL.Query().Get("enemy")
+	stream(w, r, true,
+		func(e Event) bool {
+			return (player == "" || e.Player == player) && (enemy == "" || e.Enemy == enemy)
+		},
+		func(Event) bool { return false })
+}
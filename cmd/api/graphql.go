@@ -0,0 +1,233 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/Uemerson/go-simple-rpg-api/graphql"
+)
+
+// The GraphQL API serves the subset of GraphQL the UI needs over a read-only
+// schema of the game data.
+
+const gqlDefaultLimit = 20
+
+// pageArguments are the arguments of the list fields backed by a
+// Collection: pagination, sorting and its filters as strings.
+func pageArguments[T any](c Collection[T]) []graphql.Argument {
+	args := []graphql.Argument{
+		{Name: "limit", Type: "Int!", Default: int64(gqlDefaultLimit)},
+		{Name: "offset", Type: "Int!", Default: int64(0)},
+		{Name: "sort", Type: "String"},
+	}
+	names := make([]string, 0, len(c.Filters))
+	for name := range c.Filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, graphql.Argument{Name: name, Type: "String"})
+	}
+	return args
+}
+
+func resolvePage[T any](c Collection[T], records func() []T) graphql.Resolver {
+	return func(_ any, args map[string]any) (any, error) {
+		query := map[string][]string{}
+		for name, value := range args {
+			if value != nil {
+				query[name] = []string{fmt.Sprint(value)}
+			}
+		}
+		page, _, message := c.List(query, records())
+		if message != "" {
+			return nil, &graphql.Error{Message: message}
+		}
+		result := make([]any, len(page))
+		for i, record := range page {
+			result[i] = record
+		}
+		return result, nil
+	}
+}
+
+// lastBattles returns the most recent battle rounds first.
+func lastBattles(records []Battle, args map[string]any) []any {
+	last := int(args["last"].(int64))
+	result := []any{}
+	for i := len(records) - 1; i >= 0 && len(result) < last; i-- {
+		result = append(result, records[i])
+	}
+	return result
+}
+
+// findItem, findPlayer and findEnemy expect the lock to be held, as every
+// resolver does.
+func findItem(id string) any {
+	for _, item := range items {
+		if item.ID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func findPlayer(nickname string) any {
+	if i := playerIndex(nickname); i >= 0 {
+		return players[i]
+	}
+	return nil
+}
+
+func findEnemy(nickname string) any {
+	if i := enemyIndex(nickname); i >= 0 {
+		return enemies[i]
+	}
+	return nil
+}
+
+var lastArgument = []graphql.Argument{{Name: "last", Type: "Int!", Default: int64(10)}}
+
+var gqlSchema = graphql.NewSchema(
+	&graphql.Type{Name: "Query", Fields: []graphql.Field{
+		{Name: "player", Type: "Player", Args: []graphql.Argument{{Name: "nickname", Type: "String!"}},
+			Resolve: func(_ any, args map[string]any) (any, error) { return findPlayer(args["nickname"].(string)), nil }},
+		{Name: "players", Type: "[Player!]", Args: pageArguments(playerCollection),
+			Resolve: resolvePage(playerCollection, func() []PlayerRequest { return players })},
+		{Name: "enemy", Type: "Enemy", Args: []graphql.Argument{{Name: "nickname", Type: "String!"}},
+			Resolve: func(_ any, args map[string]any) (any, error) { return findEnemy(args["nickname"].(string)), nil }},
+		{Name: "enemies", Type: "[Enemy!]", Args: pageArguments(enemyCollection),
+			Resolve: resolvePage(enemyCollection, func() []Enemy { return enemies })},
+		{Name: "item", Type: "Item", Args: []graphql.Argument{{Name: "id", Type: "ID!"}},
+			Resolve: func(_ any, args map[string]any) (any, error) { return findItem(args["id"].(string)), nil }},
+		{Name: "items", Type: "[Item!]", Args: pageArguments(itemCollection),
+			Resolve: resolvePage(itemCollection, func() []Item { return items })},
+		{Name: "battles", Type: "[Battle!]", Args: pageArguments(battleCollection),
+			Resolve: resolvePage(battleCollection, func() []Battle { return battles })},
+	}},
+	&graphql.Type{Name: "Player", Fields: []graphql.Field{
+		{Name: "nickname", Type: "String!"},
+		{Name: "life", Type: "Int!"},
+		{Name: "attack", Type: "Int!"},
+		{Name: "defense", Type: "Int!"},
+		{Name: "speed", Type: "Int!"},
+		{Name: "item_id", Type: "String!"},
+		{Name: "owner", Type: "String!"},
+		{Name: "item", Type: "Item", Resolve: func(parent any, _ map[string]any) (any, error) {
+			return findItem(parent.(PlayerRequest).ItemID), nil
+		}},
+		{Name: "battles", Type: "[Battle!]", Args: lastArgument, Resolve: func(parent any, args map[string]any) (any, error) {
+			return lastBattles(PlayerBattles(parent.(PlayerRequest).Nickname), args), nil
+		}},
+		{Name: "stats", Type: "BattleStats!", Resolve: func(parent any, _ map[string]any) (any, error) {
+			return ComputeStats(PlayerBattles(parent.(PlayerRequest).Nickname), true), nil
+		}},
+	}},
+	&graphql.Type{Name: "Enemy", Fields: []graphql.Field{
+		{Name: "nickname", Type: "String!"},
+		{Name: "life", Type: "Int!"},
+		{Name: "attack", Type: "Int!"},
+		{Name: "defense", Type: "Int!"},
+		{Name: "speed", Type: "Int!"},
+		{Name: "item_id", Type: "String!"},
+		{Name: "behavior", Type: "String!"},
+		{Name: "template", Type: "String!"},
+		{Name: "item", Type: "Item", Resolve: func(parent any, _ map[string]any) (any, error) {
+			return findItem(parent.(Enemy).ItemID), nil
+		}},
+		{Name: "battles", Type: "[Battle!]", Args: lastArgument, Resolve: func(parent any, args map[string]any) (any, error) {
+			return lastBattles(EnemyBattles(parent.(Enemy).Nickname), args), nil
+		}},
+		{Name: "stats", Type: "BattleStats!", Resolve: func(parent any, _ map[string]any) (any, error) {
+			return ComputeStats(EnemyBattles(parent.(Enemy).Nickname), false), nil
+		}},
+	}},
+	&graphql.Type{Name: "Item", Fields: []graphql.Field{
+		{Name: "id", Type: "ID!"},
+		{Name: "name", Type: "String!"},
+		{Name: "effect_type", Type: "String!"},
+		{Name: "effect_value", Type: "Int!"},
+	}},
+	&graphql.Type{Name: "Battle", Fields: []graphql.Field{
+		{Name: "id", Type: "ID!"},
+		{Name: "session_id", Type: "ID!"},
+		{Name: "round", Type: "Int!"},
+		{Name: "player_nickname", Type: "String!", Resolve: func(parent any, _ map[string]any) (any, error) {
+			return parent.(Battle).Player, nil
+		}},
+		{Name: "enemy_nickname", Type: "String!", Resolve: func(parent any, _ map[string]any) (any, error) {
+			return parent.(Battle).Enemy, nil
+		}},
+		{Name: "player", Type: "Player", Resolve: func(parent any, _ map[string]any) (any, error) {
+			return findPlayer(parent.(Battle).Player), nil
+		}},
+		{Name: "enemy", Type: "Enemy", Resolve: func(parent any, _ map[string]any) (any, error) {
+			return findEnemy(parent.(Battle).Enemy), nil
+		}},
+		{Name: "dice_thrown", Type: "Int!"},
+		{Name: "action", Type: "String!"},
+		{Name: "enemy_action", Type: "String!"},
+		{Name: "player_damage", Type: "Int!"},
+		{Name: "enemy_damage", Type: "Int!"},
+		{Name: "outcome", Type: "String"},
+		{Name: "created_at", Type: "String!"},
+	}},
+	&graphql.Type{Name: "BattleStats", Fields: []graphql.Field{
+		{Name: "battles", Type: "Int!"},
+		{Name: "wins", Type: "Int!"},
+		{Name: "losses", Type: "Int!"},
+		{Name: "win_rate", Type: "Float!"},
+		{Name: "damage_dealt", Type: "Int!"},
+		{Name: "damage_taken", Type: "Int!"},
+		{Name: "average_dice", Type: "Float!"},
+		{Name: "longest_battle", Type: "Int!"},
+	}},
+)
+
+func writeGraphQL(w http.ResponseWriter, status int, response graphql.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// maxGraphQLBody bounds the request body, leaving room for the variables
+// next to the longest query the parser accepts.
+const maxGraphQLBody = 4 * graphql.MaxQueryLength
+
+// GraphQL runs a query. Requests that cannot run, because the query is
+// invalid or over the limits, are answered with 400 and only errors; errors
+// resolving fields are reported next to the data.
+func GraphQL(w http.ResponseWriter, r *http.Request) {
+	var request graphql.Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLBody)).Decode(&request); err != nil {
+		writeGraphQL(w, http.StatusBadRequest, graphql.Response{Errors: []*graphql.Error{{Message: "Invalid request body"}}})
+		return
+	}
+	q, err := gqlSchema.Prepare(request)
+	if err == nil {
+		var complexity, depth int
+		if complexity, depth, err = q.Cost(); err == nil {
+			if depth > config.Server.GraphQLMaxDepth {
+				err = &graphql.Error{Message: fmt.Sprintf("Query depth %d exceeds the maximum of %d", depth, config.Server.GraphQLMaxDepth)}
+			} else if complexity > config.Server.GraphQLMaxComplexity {
+				err = &graphql.Error{Message: fmt.Sprintf("Query complexity %d exceeds the maximum of %d", complexity, config.Server.GraphQLMaxComplexity)}
+			}
+		}
+	}
+	if err != nil {
+		writeGraphQL(w, http.StatusBadRequest, graphql.Response{Errors: []*graphql.Error{err.(*graphql.Error)}})
+		return
+	}
+	// The resolvers read the game state directly, so the whole query sees
+	// one consistent state.
+	var response graphql.Response
+	game.View(func() { response = q.Execute() })
+	writeGraphQL(w, http.StatusOK, response)
+}
+
+func LoadGraphQLSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, gqlSchema)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Uemerson/go-simple-rpg-api/graphql"
+)
+
+func TestGraphQL(t *testing.T) {
+	players = []PlayerRequest{{Nickname: "Hero", Life: 10}}
+	battles = []Battle{{Player: "Hero", Enemy: "Slime", Round: 1}, {Player: "Hero", Enemy: "Slime", Round: 2}}
+	defer func() { players, battles = nil, nil }()
+	for _, tt := range []struct {
+		query  string
+		status int
+		want   string
+	}{
+		{`{ player(nickname: "Hero") { life battles(last: 1) { round } } }`, http.StatusOK, `{"data":{"player":{"life":10,"battles":[{"round":2}]}}}`},
+		{`{ players(limit: 5) { nickname } }`, http.StatusOK, `{"data":{"players":[{"nickname":"Hero"}]}}`},
+		{`{ players(sort: "mood") { nickname } }`, http.StatusOK, `{"data":{"players":null},"errors":[{"message":"Invalid sort field mood","locations":[{"line":1,"column":3}],"path":["players"]}]}`},
+		{`{ battles { player { battles { enemy { battles { player { battles { round } } } } } } } }`, http.StatusBadRequest, `Query depth 8 exceeds the maximum of 6`},
+		{`{ players(limit: 100) { battles(last: 100) { player { nickname } } } }`, http.StatusBadRequest, `Query complexity 20101 exceeds the maximum of 2000`},
+		{`{ players { battles(last: null) { round } } }`, http.StatusBadRequest, `expected a non-null Int`},
+	} {
+		body, _ := json.Marshal(graphql.Request{Query: tt.query})
+		w := httptest.NewRecorder()
+		GraphQL(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+		if w.Code != tt.status || !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s: status %d, body %s; want %d with %s", tt.query, w.Code, w.Body, tt.status, tt.want)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Uemerson/go-simple-rpg-api/rpgpb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcMethod authorizes a gRPC method like the route of the matching HTTP
+// handler: Role is the role required, none for public methods, and
+// RateLimit the route whose rate limit applies.
+type grpcMethod struct {
+	Role      string
+	RateLimit string
+}
+
+var grpcMethods = map[string]grpcMethod{
+	rpgpb.PlayerService_AddPlayer_FullMethodName:    {Role: RolePlayer, RateLimit: "POST /player"},
+	rpgpb.PlayerService_SavePlayer_FullMethodName:   {Role: RolePlayer},
+	rpgpb.PlayerService_DeletePlayer_FullMethodName: {Role: RolePlayer},
+	rpgpb.EnemyService_AddEnemy_FullMethodName:      {Role: RoleGameMaster, RateLimit: "POST /enemy"},
+	rpgpb.EnemyService_UpdateEnemy_FullMethodName:   {Role: RoleGameMaster},
+	rpgpb.EnemyService_DeleteEnemy_FullMethodName:   {Role: RoleGameMaster},
+	rpgpb.ItemService_AddItem_FullMethodName:        {Role: RoleGameMaster, RateLimit: "POST /item"},
+	rpgpb.BattleService_CreateBattle_FullMethodName: {Role: RolePlayer, RateLimit: "POST /battle"},
+}
+
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:      codes.InvalidArgument,
+	http.StatusUnauthorized:    codes.Unauthenticated,
+	http.StatusForbidden:       codes.PermissionDenied,
+	http.StatusNotFound:        codes.NotFound,
+	http.StatusTooManyRequests: codes.ResourceExhausted,
+}
+
+// grpcError converts game errors to statuses with the matching code and
+// hides the message of other errors.
+func grpcError(ctx context.Context, err error) error {
+	var gameErr *GameError
+	if !errors.As(err, &gameErr) {
+		ContextLogger(ctx).Error("call failed", "error", err)
+		return status.Error(codes.Internal, "Internal Server Error")
+	}
+	code, ok := grpcCodes[gameErr.Status]
+	if !ok {
+		code = codes.Internal
+	}
+	if gameErr.RetryAfter > 0 {
+		grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(gameErr.RetryAfter.Seconds())))))
+	}
+	return status.Error(code, gameErr.Message)
+}
+
+// authorize authenticates the call when its method requires a role and
+// applies its rate limit, returning the context the handler runs with.
+func authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	method := grpcMethods[fullMethod]
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := uuid.NewString()
+	if ids := md.Get("x-request-id"); len(ids) > 0 && ids[0] != "" && len(ids[0]) <= 128 {
+		id = ids[0]
+	}
+	ctx = context.WithValue(ctx, requestIDKey, id)
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", id))
+
+	var account *Account
+	var authErr error = gameError(http.StatusUnauthorized, "Authentication required")
+	if authorization := md.Get("authorization"); len(authorization) > 0 {
+		if token, ok := strings.CutPrefix(authorization[0], "Bearer "); ok {
+			account, authErr = authenticate(token)
+		}
+	}
+	if limiter, ok := rateLimiters[method.RateLimit]; ok {
+		key := "ip:"
+		if authErr == nil {
+			key = "account:" + account.Username
+		} else if p, ok := peer.FromContext(ctx); ok {
+			host, _, err := net.SplitHostPort(p.Addr.String())
+			if err != nil {
+				host = p.Addr.String()
+			}
+			key += host
+		}
+		if allowed, wait := limiter.Allow(key); !allowed {
+			return ctx, &GameError{Status: http.StatusTooManyRequests, Message: "Too many requests", RetryAfter: wait}
+		}
+	}
+	if method.Role == "" {
+		return ctx, nil
+	}
+	if authErr != nil {
+		return ctx, authErr
+	}
+	if !HasRole(account, method.Role) {
+		return ctx, gameError(http.StatusForbidden, "The "+method.Role+" role is required")
+	}
+	return context.WithValue(ctx, accountKey, account), nil
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	ContextLogger(ctx).Info("call", "method", method, "code", status.Code(err).String(), "latency", time.Since(start))
+}
+
+func unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	ctx, err := authorize(ctx, info.FullMethod)
+	var resp any
+	if err == nil {
+		resp, err = handler(ctx, req)
+	}
+	if err != nil {
+		err = grpcError(ctx, err)
+	}
+	logCall(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, err := authorize(ss.Context(), info.FullMethod)
+	if err == nil {
+		err = handler(srv, contextStream{ss, ctx})
+	}
+	if err != nil {
+		err = grpcError(ctx, err)
+	}
+	logCall(ctx, info.FullMethod, start, err)
+	return err
+}
+
+func NewGRPCServer() *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(unaryInterceptor), grpc.StreamInterceptor(streamInterceptor))
+	rpgpb.RegisterPlayerServiceServer(server, playerServer{})
+	rpgpb.RegisterEnemyServiceServer(server, enemyServer{})
+	rpgpb.RegisterItemServiceServer(server, itemServer{})
+	rpgpb.RegisterBattleServiceServer(server, battleServer{})
+	return server
+}
+
+// queryOf converts a list request to the query parameters the
+// collections expect.
+func queryOf(req *rpgpb.ListRequest) map[string][]string {
+	query := map[string][]string{}
+	for name, value := range req.GetFilters() {
+		query[name] = []string{value}
+	}
+	if req.GetLimit() > 0 {
+		query["limit"] = []string{strconv.Itoa(int(req.GetLimit()))}
+	}
+	if req.GetOffset() > 0 {
+		query["offset"] = []string{strconv.Itoa(int(req.GetOffset()))}
+	}
+	if req.GetSort() != "" {
+		query["sort"] = []string{req.GetSort()}
+	}
+	return query
+}
+
+func listPage[T, M any](c Collection[T], req *rpgpb.ListRequest, records []T, convert func(T) M) ([]M, int32, error) {
+	page, total, message := c.List(queryOf(req), records)
+	if message != "" {
+		return nil, 0, gameError(http.StatusBadRequest, message)
+	}
+	converted := make([]M, len(page))
+	for i, record := range page {
+		converted[i] = convert(record)
+	}
+	return converted, int32(total), nil
+}
+
+func playerToPB(p PlayerRequest) *rpgpb.Player {
+	return &rpgpb.Player{Nickname: p.Nickname, Life: int32(p.Life), Attack: int32(p.Attack), Defense: int32(p.Defense), Speed: int32(p.Speed), ItemId: p.ItemID, Owner: p.Owner}
+}
+
+func playerFromPB(p *rpgpb.Player) PlayerRequest {
+	return PlayerRequest{Nickname: p.GetNickname(), Life: int(p.GetLife()), Attack: int(p.GetAttack()), Defense: int(p.GetDefense()), Speed: int(p.GetSpeed()), ItemID: p.GetItemId()}
+}
+
+func enemyToPB(e Enemy) *rpgpb.Enemy {
+	return &rpgpb.Enemy{Nickname: e.Nickname, Life: int32(e.Life), Attack: int32(e.Attack), Defense: int32(e.Defense), Speed: int32(e.Speed), ItemId: e.ItemID, Behavior: e.Behavior, Template: e.Template}
+}
+
+func enemyFromPB(e *rpgpb.Enemy) Enemy {
+	return Enemy{Nickname: e.GetNickname(), Life: int(e.GetLife()), Attack: int(e.GetAttack()), Defense: int(e.GetDefense()), Speed: int(e.GetSpeed()), ItemID: e.GetItemId(), Behavior: e.GetBehavior(), Template: e.GetTemplate()}
+}
+
+func itemToPB(item Item) *rpgpb.Item {
+	return &rpgpb.Item{Id: item.ID, Name: item.Name, EffectType: item.EffectType, EffectValue: int32(item.EffectValue)}
+}
+
+func battleToPB(b Battle) *rpgpb.Battle {
+	return &rpgpb.Battle{
+		Id:           b.ID,
+		SessionId:    b.SessionID,
+		Round:        int32(b.Round),
+		Enemy:        b.Enemy,
+		Player:       b.Player,
+		DiceThrown:   int32(b.DiceThrown),
+		Action:       string(b.Action),
+		EnemyAction:  string(b.EnemyAction),
+		PlayerDamage: int32(b.PlayerDamage),
+		EnemyDamage:  int32(b.EnemyDamage),
+		Outcome:      b.Outcome,
+		CreatedAt:    timestamppb.New(b.CreatedAt),
+	}
+}
+
+func eventToPB(e Event) *rpgpb.Event {
+	event := &rpgpb.Event{
+		Id:        e.ID,
+		Type:      e.Type,
+		SessionId: e.SessionID,
+		Player:    e.Player,
+		Enemy:     e.Enemy,
+		Target:    e.Target,
+		Outcome:   e.Outcome,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	if e.Battle != nil {
+		event.Battle = battleToPB(*e.Battle)
+	}
+	if e.Item != nil {
+		event.Item = itemToPB(*e.Item)
+	}
+	return event
+}
+
+type playerServer struct {
+	rpgpb.UnimplementedPlayerServiceServer
+}
+
+func (playerServer) AddPlayer(ctx context.Context, req *rpgpb.Player) (*rpgpb.Player, error) {
+	player, err := game.AddPlayer(ctx, playerFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+	return playerToPB(player), nil
+}
+
+func (playerServer) LoadPlayers(ctx context.Context, req *rpgpb.ListRequest) (*rpgpb.PlayerList, error) {
+	page, total, err := listPage(playerCollection, req, game.Players(), playerToPB)
+	if err != nil {
+		return nil, err
+	}
+	return &rpgpb.PlayerList{Players: page, Total: total}, nil
+}
+
+func (playerServer) LoadPlayerByNickname(ctx context.Context, req *rpgpb.NicknameRequest) (*rpgpb.Player, error) {
+	player, err := game.LoadPlayerByNickname(req.GetNickname())
+	if err != nil {
+		return nil, err
+	}
+	return playerToPB(player), nil
+}
+
+func (playerServer) SavePlayer(ctx context.Context, req *rpgpb.SavePlayerRequest) (*rpgpb.Player, error) {
+	player, err := game.SavePlayer(ctx, req.GetNickname(), playerFromPB(req.GetPlayer()))
+	if err != nil {
+		return nil, err
+	}
+	return playerToPB(player), nil
+}
+
+func (playerServer) DeletePlayer(ctx context.Context, req *rpgpb.NicknameRequest) (*emptypb.Empty, error) {
+	if err := game.DeletePlayer(ctx, req.GetNickname()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+type enemyServer struct {
+	rpgpb.UnimplementedEnemyServiceServer
+}
+
+func (enemyServer) AddEnemy(ctx context.Context, req *rpgpb.Enemy) (*rpgpb.Enemy, error) {
+	enemy, err := game.AddEnemy(ctx, enemyFromPB(req))
+	if err != nil {
+		return nil, err
+	}
+	return enemyToPB(enemy), nil
+}
+
+func (enemyServer) LoadEnemies(ctx context.Context, req *rpgpb.ListRequest) (*rpgpb.EnemyList, error) {
+	page, total, err := listPage(enemyCollection, req, game.Enemies(), enemyToPB)
+	if err != nil {
+		return nil, err
+	}
+	return &rpgpb.EnemyList{Enemies: page, Total: total}, nil
+}
+
+func (enemyServer) LoadEnemyByNickname(ctx context.Context, req *rpgpb.NicknameRequest) (*rpgpb.Enemy, error) {
+	enemy, err := game.LoadEnemyByNickname(req.GetNickname())
+	if err != nil {
+		return nil, err
+	}
+	return enemyToPB(enemy), nil
+}
+
+func (enemyServer) UpdateEnemy(ctx context.Context, req *rpgpb.UpdateEnemyRequest) (*rpgpb.Enemy, error) {
+	enemy, err := game.UpdateEnemy(ctx, req.GetNickname(), enemyFromPB(req.GetEnemy()))
+	if err != nil {
+		return nil, err
+	}
+	return enemyToPB(enemy), nil
+}
+
+func (enemyServer) DeleteEnemy(ctx context.Context, req *rpgpb.NicknameRequest) (*emptypb.Empty, error) {
+	if err := game.DeleteEnemy(ctx, req.GetNickname()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+type itemServer struct {
+	rpgpb.UnimplementedItemServiceServer
+}
+
+func (itemServer) AddItem(ctx context.Context, req *rpgpb.Item) (*rpgpb.Item, error) {
+	item, err := game.AddItem(ctx, Item{Name: req.GetName(), EffectType: req.GetEffectType(), EffectValue: int(req.GetEffectValue())})
+	if err != nil {
+		return nil, err
+	}
+	return itemToPB(item), nil
+}
+
+func (itemServer) LoadItems(ctx context.Context, req *rpgpb.ListRequest) (*rpgpb.ItemList, error) {
+	page, total, err := listPage(itemCollection, req, game.Items(), itemToPB)
+	if err != nil {
+		return nil, err
+	}
+	return &rpgpb.ItemList{Items: page, Total: total}, nil
+}
+
+type battleServer struct {
+	rpgpb.UnimplementedBattleServiceServer
+}
+
+func (battleServer) CreateBattle(ctx context.Context, req *rpgpb.BattleRequest) (*rpgpb.Battle, error) {
+	battle, err := game.CreateBattle(ctx, BattleRequest{Player: req.GetPlayer(), Enemy: req.GetEnemy(), Action: Action(req.GetAction())})
+	if err != nil {
+		return nil, err
+	}
+	return battleToPB(battle), nil
+}
+
+func (battleServer) LoadBattles(ctx context.Context, req *rpgpb.ListRequest) (*rpgpb.BattleList, error) {
+	page, total, err := listPage(battleCollection, req, game.Battles(), battleToPB)
+	if err != nil {
+		return nil, err
+	}
+	return &rpgpb.BattleList{Battles: page, Total: total}, nil
+}
+
+// StreamBattleEvents sends the events kept after last_event_id, then the
+// live ones. Like the Server-Sent Events routes, the stream of a battle
+// session ends with the battle.
+func (battleServer) StreamBattleEvents(req *rpgpb.StreamEventsRequest, stream grpc.ServerStreamingServer[rpgpb.Event]) error {
+	follow := true
+	if req.GetSessionId() != "" {
+		session, ok := game.Session(req.GetSessionId())
+		if !ok {
+			return gameError(http.StatusNotFound, "Battle session not found")
+		}
+		follow = session.Status == SessionActive
+	}
+	missed, live, cancel := events.Subscribe(req.GetLastEventId(), func(e Event) bool {
+		return (req.GetSessionId() == "" || e.SessionID == req.GetSessionId()) &&
+			(req.GetPlayer() == "" || e.Player == req.GetPlayer()) &&
+			(req.GetEnemy() == "" || e.Enemy == req.GetEnemy())
+	})
+	defer cancel()
+	send := func(event Event) (bool, error) {
+		if err := stream.Send(eventToPB(event)); err != nil {
+			return true, err
+		}
+		return req.GetSessionId() != "" && event.Type == EventEnd, nil
+	}
+	for _, event := range missed {
+		if done, err := send(event); done {
+			return err
+		}
+	}
+	if !follow {
+		return nil
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-live:
+			if !ok {
+				return nil
+			}
+			if done, err := send(event); done {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// ready is false until the game data is loaded and again once the server
+// starts shutting down, so load balancers stop sending new requests.
+var ready atomic.Bool
+
+func Healthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PlayerResponse{Message: "ok"})
+}
+
+func Readyz(store *Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !ready.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(PlayerResponse{Message: "Server is not ready"})
+			return
+		}
+		if err := store.Available(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(PlayerResponse{Message: "Storage is unavailable: " + err.Error()})
+			return
+		}
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "ready"})
+	}
+}
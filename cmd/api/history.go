@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type BattleStats struct {
+	Battles       int     `json:"battles"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	WinRate       float64 `json:"win_rate"`
+	DamageDealt   int     `json:"damage_dealt"`
+	DamageTaken   int     `json:"damage_taken"`
+	AverageDice   float64 `json:"average_dice"`
+	LongestBattle int     `json:"longest_battle"`
+}
+
+// PlayerBattles returns the battle rounds of the player. It expects the lock
+// to be held, as do EnemyBattles, playerExists and enemyExists.
+func PlayerBattles(nickname string) []Battle {
+	result := []Battle{}
+	for _, battle := range battles {
+		if battle.Player == nickname {
+			result = append(result, battle)
+		}
+	}
+	return result
+}
+
+func EnemyBattles(nickname string) []Battle {
+	result := []Battle{}
+	for _, battle := range battles {
+		if battle.Enemy == nickname {
+			result = append(result, battle)
+		}
+	}
+	return result
+}
+
+// ComputeStats aggregates battle rounds from the point of view of the player
+// or of the enemy, a battle being every round sharing the same session.
+func ComputeStats(records []Battle, asPlayer bool) BattleStats {
+	var stats BattleStats
+	rounds := map[string]int{}
+	diceTotal := 0
+	for _, battle := range records {
+		rounds[battle.SessionID]++
+		diceTotal += battle.DiceThrown
+		dealt, taken := battle.PlayerDamage, battle.EnemyDamage
+		won, lost := battle.Outcome == SessionPlayerWon, battle.Outcome == SessionEnemyWon
+		if !asPlayer {
+			dealt, taken = taken, dealt
+			won, lost = lost, won
+		}
+		stats.DamageDealt += dealt
+		stats.DamageTaken += taken
+		if won {
+			stats.Wins++
+		}
+		if lost {
+			stats.Losses++
+		}
+	}
+	for _, count := range rounds {
+		stats.LongestBattle = max(stats.LongestBattle, count)
+	}
+	stats.Battles = len(rounds)
+	if stats.Battles > 0 {
+		stats.WinRate = float64(stats.Wins) / float64(stats.Battles)
+	}
+	if len(records) > 0 {
+		stats.AverageDice = float64(diceTotal) / float64(len(records))
+	}
+	return stats
+}
+
+func playerExists(nickname string) bool {
+	return playerIndex(nickname) >= 0
+}
+
+func enemyExists(nickname string) bool {
+	return enemyIndex(nickname) >= 0
+}
+
+// playerBattles returns the battle rounds of the player, false when there is
+// no such player.
+func playerBattles(nickname string) (records []Battle, ok bool) {
+	game.View(func() {
+		if ok = playerExists(nickname); ok {
+			records = PlayerBattles(nickname)
+		}
+	})
+	return records, ok
+}
+
+// enemyBattles returns the battle rounds of the enemy, false when there is no
+// such enemy.
+func enemyBattles(nickname string) (records []Battle, ok bool) {
+	game.View(func() {
+		if ok = enemyExists(nickname); ok {
+			records = EnemyBattles(nickname)
+		}
+	})
+	return records, ok
+}
+
+func LoadPlayerBattles(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	records, ok := playerBattles(r.PathValue("nickname"))
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Player nickname not found"})
+		return
+	}
+	json.NewEncoder(w).Encode(records)
+}
+
+func LoadPlayerStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	records, ok := playerBattles(r.PathValue("nickname"))
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Player nickname not found"})
+		return
+	}
+	json.NewEncoder(w).Encode(ComputeStats(records, true))
+}
+
+func LoadEnemyBattles(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	records, ok := enemyBattles(r.PathValue("nickname"))
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Enemy nickname not found"})
+		return
+	}
+	json.NewEncoder(w).Encode(records)
+}
+
+func LoadEnemyStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	records, ok := enemyBattles(r.PathValue("nickname"))
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Enemy nickname not found"})
+		return
+	}
+	json.NewEncoder(w).Encode(ComputeStats(records, false))
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxIdempotentBody is the largest request body read to fingerprint a request
+// sent with an Idempotency-Key.
+const maxIdempotentBody = 1 << 20
+
+// An idempotentResponse is the response stored for an Idempotency-Key, or a
+// request still in progress when done is false. Both are forgotten once they
+// expire.
+type idempotentResponse struct {
+	fingerprint [sha256.Size]byte
+	done        bool
+	status      int
+	contentType string
+	body        []byte
+	expires     time.Time
+}
+
+// IdempotencyStore keeps the responses of requests sent with an
+// Idempotency-Key for the configured window, in memory only.
+type IdempotencyStore struct {
+	Now       func() time.Time
+	mu        sync.Mutex
+	responses map[string]*idempotentResponse
+	lastSweep time.Time
+}
+
+var idempotency = &IdempotencyStore{Now: time.Now, responses: map[string]*idempotentResponse{}}
+
+// begin returns the stored response of key, or nil after reserving key for a
+// new request. Expired keys are swept at most once a minute.
+func (s *IdempotencyStore) begin(key string, fingerprint [sha256.Size]byte) *idempotentResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.Now()
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, response := range s.responses {
+			if !now.Before(response.expires) {
+				delete(s.responses, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	if response, ok := s.responses[key]; ok && now.Before(response.expires) {
+		copied := *response
+		return &copied
+	}
+	s.responses[key] = &idempotentResponse{fingerprint: fingerprint, expires: now.Add(s.window())}
+	return nil
+}
+
+// window is how long keys are kept.
+func (s *IdempotencyStore) window() time.Duration {
+	return config.Server.IdempotencyWindow.Duration
+}
+
+// finish stores the response of key, or releases key when the response
+// should not be replayed.
+func (s *IdempotencyStore) finish(key string, response *idempotentResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if response == nil {
+		delete(s.responses, key)
+		return
+	}
+	response.done = true
+	response.expires = s.Now().Add(s.window())
+	s.responses[key] = response
+}
+
+// bodyRecorder keeps a copy of the response written by a handler.
+type bodyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bodyRecorder) WriteHeader(status int) {
+	b.status = status
+	b.ResponseWriter.WriteHeader(status)
+}
+
+func (b *bodyRecorder) Write(data []byte) (int, error) {
+	b.body.Write(data)
+	return b.ResponseWriter.Write(data)
+}
+
+func (b *bodyRecorder) Unwrap() http.ResponseWriter {
+	return b.ResponseWriter
+}
+
+func writeIdempotencyError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(PlayerResponse{Message: message})
+}
+
+// Idempotent replays the stored response when a client sends the same
+// Idempotency-Key again, so retried requests are processed once. Keys are
+// scoped to the route and the client, reusing one with a different body is
+// rejected, and responses of failed requests (429 and 5xx) are not stored so
+// the client can retry them.
+func Idempotent(route string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > 255 {
+			writeIdempotencyError(w, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBody))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeIdempotencyError(w, http.StatusRequestEntityTooLarge, "Request body is too large")
+			return
+		}
+		if err != nil {
+			writeIdempotencyError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := sha256.Sum256(body)
+		key = route + "\x00" + clientKey(r) + "\x00" + key
+
+		if stored := idempotency.begin(key, fingerprint); stored != nil {
+			switch {
+			case stored.fingerprint != fingerprint:
+				writeIdempotencyError(w, http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request body")
+			case !stored.done:
+				writeIdempotencyError(w, http.StatusConflict, "A request with this Idempotency-Key is still in progress")
+			default:
+				if stored.contentType != "" {
+					w.Header().Set("Content-Type", stored.contentType)
+				}
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.status)
+				w.Write(stored.body)
+			}
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: w, status: http.StatusOK}
+		completed := false
+		defer func() {
+			if !completed || recorder.status == http.StatusTooManyRequests || recorder.status >= 500 {
+				idempotency.finish(key, nil)
+				return
+			}
+			idempotency.finish(key, &idempotentResponse{
+				fingerprint: fingerprint,
+				status:      recorder.status,
+				contentType: recorder.Header().Get("Content-Type"),
+				body:        recorder.body.Bytes(),
+			})
+		}()
+		next(recorder, r)
+		completed = true
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdempotentReplaysResponses(t *testing.T) {
+	idempotency = &IdempotencyStore{Now: time.Now, responses: map[string]*idempotentResponse{}}
+	calls := 0
+	handler := Idempotent("POST /thing", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1}`))
+	})
+	send := func(key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/thing", strings.NewReader(body))
+		r.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w
+	}
+
+	first, second := send("a", `{"n":1}`), send("a", `{"n":1}`)
+	if calls != 1 || second.Code != http.StatusCreated || second.Body.String() != first.Body.String() || second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("repeated request: %d calls, status %d, body %q", calls, second.Code, second.Body)
+	}
+	if w := send("a", `{"n":2}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("key reused with another body: status %d, want 422", w.Code)
+	}
+	if w := send("b", strings.Repeat("x", maxIdempotentBody+1)); w.Code != http.StatusRequestEntityTooLarge || calls != 1 {
+		t.Errorf("large body: status %d after %d calls, want 413 without calling the handler", w.Code, calls)
+	}
+}
+
+func TestIdempotencyStoreExpiresKeys(t *testing.T) {
+	now := time.Now()
+	store := &IdempotencyStore{Now: func() time.Time { return now }, responses: map[string]*idempotentResponse{}}
+	window := config.Server.IdempotencyWindow.Duration
+	var fingerprint [sha256.Size]byte
+
+	store.begin("done", fingerprint)
+	store.finish("done", &idempotentResponse{fingerprint: fingerprint, status: http.StatusOK})
+	store.begin("stuck", fingerprint)
+	if store.begin("done", fingerprint) == nil || store.begin("stuck", fingerprint) == nil {
+		t.Fatal("keys forgotten within the window")
+	}
+
+	now = now.Add(window + time.Minute)
+	if store.begin("other", fingerprint) != nil {
+		t.Fatal("new key already reserved")
+	}
+	if _, ok := store.responses["done"]; ok {
+		t.Error("expired response kept")
+	}
+	if _, ok := store.responses["stuck"]; ok {
+		t.Error("expired reservation kept")
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const xpPerWin = 50
+const xpPerLevel = 100
+
+type Score struct {
+	Player          string `json:"player"`
+	Wins            int    `json:"wins"`
+	XP              int    `json:"xp"`
+	Level           int    `json:"level"`
+	EnemiesDefeated int    `json:"enemies_defeated"`
+	HighestHit      int    `json:"highest_hit"`
+	defeated        map[string]bool
+}
+
+// A Leaderboard keeps running scores per player, updated as battle rounds
+// are recorded instead of rescanning every battle on each request.
+type Leaderboard struct {
+	scores map[string]*Score
+}
+
+var leaderboardSorts = map[string]func(a, b *Score) int{
+	"wins":             func(a, b *Score) int { return cmp.Compare(a.Wins, b.Wins) },
+	"xp":               func(a, b *Score) int { return cmp.Compare(a.XP, b.XP) },
+	"enemies_defeated": func(a, b *Score) int { return cmp.Compare(a.EnemiesDefeated, b.EnemiesDefeated) },
+	"highest_hit":      func(a, b *Score) int { return cmp.Compare(a.HighestHit, b.HighestHit) },
+}
+
+type LeaderboardResponse struct {
+	Board   string  `json:"board"`
+	Window  string  `json:"window"`
+	Entries []Score `json:"entries"`
+}
+
+var allTimeLeaderboard = NewLeaderboard()
+var weeklyLeaderboards = map[string]*Leaderboard{}
+
+func NewLeaderboard() *Leaderboard {
+	return &Leaderboard{scores: map[string]*Score{}}
+}
+
+func (l *Leaderboard) Record(battle Battle) {
+	score, ok := l.scores[battle.Player]
+	if !ok {
+		score = &Score{Player: battle.Player, Level: 1, defeated: map[string]bool{}}
+		l.scores[battle.Player] = score
+	}
+	score.XP += battle.PlayerDamage
+	score.HighestHit = max(score.HighestHit, battle.PlayerDamage)
+	if battle.Outcome == SessionPlayerWon {
+		score.Wins++
+		score.XP += xpPerWin
+		score.defeated[battle.Enemy] = true
+		score.EnemiesDefeated = len(score.defeated)
+	}
+	score.Level = 1 + score.XP/xpPerLevel
+}
+
+func (l *Leaderboard) Top(board string, limit int) []Score {
+	scores := make([]*Score, 0, len(l.scores))
+	for _, score := range l.scores {
+		scores = append(scores, score)
+	}
+	compare := leaderboardSorts[board]
+	slices.SortFunc(scores, func(a, b *Score) int {
+		if order := compare(b, a); order != 0 {
+			return order
+		}
+		return cmp.Compare(a.Player, b.Player)
+	})
+	result := []Score{}
+	for _, score := range scores[:min(limit, len(scores))] {
+		result = append(result, *score)
+	}
+	return result
+}
+
+func weekKey(t time.Time) string {
+	year, week := t.ISOWeek()
+	return strconv.Itoa(year) + "-W" + strconv.Itoa(week)
+}
+
+// RecordLeaderboards adds the battle round to the leaderboards. It expects
+// the lock to be held.
+func RecordLeaderboards(battle Battle) {
+	allTimeLeaderboard.Record(battle)
+	key := weekKey(battle.CreatedAt)
+	if _, ok := weeklyLeaderboards[key]; !ok {
+		weeklyLeaderboards[key] = NewLeaderboard()
+	}
+	weeklyLeaderboards[key].Record(battle)
+}
+
+func LoadLeaderboard(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	board := r.URL.Query().Get("board")
+	if board == "" {
+		board = "wins"
+	}
+	if _, ok := leaderboardSorts[board]; !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Leaderboard must be wins, xp, enemies_defeated or highest_hit"})
+		return
+	}
+	limit := 10
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(PlayerResponse{Message: "Invalid value for limit"})
+			return
+		}
+		limit = n
+	}
+
+	window := r.URL.Query().Get("window")
+	switch window {
+	case "", "all":
+		window = "all"
+	case "weekly":
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Leaderboard window must be all or weekly"})
+		return
+	}
+
+	entries := []Score{}
+	game.View(func() {
+		leaderboard := allTimeLeaderboard
+		if window == "weekly" {
+			leaderboard = weeklyLeaderboards[weekKey(time.Now())]
+		}
+		if leaderboard != nil {
+			entries = leaderboard.Top(board, limit)
+		}
+	})
+	json.NewEncoder(w).Encode(LeaderboardResponse{
+		Board:   board,
+		Window:  window,
+		Entries: entries,
+	})
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Filter parses the value of a query parameter into a predicate over the
+// records of a collection.
+type Filter[T any] func(value string) (func(T) bool, error)
+
+// A Collection describes which query parameters can filter a list endpoint
+// and which fields it can be sorted by.
+type Collection[T any] struct {
+	Filters map[string]Filter[T]
+	Sorts   map[string]func(a, b T) int
+}
+
+var playerCollection = Collection[PlayerRequest]{
+	Filters: map[string]Filter[PlayerRequest]{
+		"min_life":   MinFilter(func(p PlayerRequest) int { return p.Life }),
+		"max_life":   MaxFilter(func(p PlayerRequest) int { return p.Life }),
+		"min_attack": MinFilter(func(p PlayerRequest) int { return p.Attack }),
+		"max_attack": MaxFilter(func(p PlayerRequest) int { return p.Attack }),
+		"alive":      AliveFilter(func(p PlayerRequest) int { return p.Life }),
+		"item_id":    EqualFilter(func(p PlayerRequest) string { return p.ItemID }),
+	},
+	Sorts: map[string]func(a, b PlayerRequest) int{
+		"nickname": func(a, b PlayerRequest) int { return cmp.Compare(a.Nickname, b.Nickname) },
+		"life":     func(a, b PlayerRequest) int { return cmp.Compare(a.Life, b.Life) },
+		"attack":   func(a, b PlayerRequest) int { return cmp.Compare(a.Attack, b.Attack) },
+		"defense":  func(a, b PlayerRequest) int { return cmp.Compare(a.Defense, b.Defense) },
+		"speed":    func(a, b PlayerRequest) int { return cmp.Compare(a.Speed, b.Speed) },
+	},
+}
+
+var enemyCollection = Collection[Enemy]{
+	Filters: map[string]Filter[Enemy]{
+		"min_life":   MinFilter(func(e Enemy) int { return e.Life }),
+		"max_life":   MaxFilter(func(e Enemy) int { return e.Life }),
+		"min_attack": MinFilter(func(e Enemy) int { return e.Attack }),
+		"max_attack": MaxFilter(func(e Enemy) int { return e.Attack }),
+		"alive":      AliveFilter(func(e Enemy) int { return e.Life }),
+		"behavior":   EqualFilter(func(e Enemy) string { return e.Behavior }),
+	},
+	Sorts: map[string]func(a, b Enemy) int{
+		"nickname": func(a, b Enemy) int { return cmp.Compare(a.Nickname, b.Nickname) },
+		"life":     func(a, b Enemy) int { return cmp.Compare(a.Life, b.Life) },
+		"attack":   func(a, b Enemy) int { return cmp.Compare(a.Attack, b.Attack) },
+		"defense":  func(a, b Enemy) int { return cmp.Compare(a.Defense, b.Defense) },
+		"speed":    func(a, b Enemy) int { return cmp.Compare(a.Speed, b.Speed) },
+	},
+}
+
+var battleCollection = Collection[Battle]{
+	Filters: map[string]Filter[Battle]{
+		"player":     EqualFilter(func(b Battle) string { return b.Player }),
+		"enemy":      EqualFilter(func(b Battle) string { return b.Enemy }),
+		"session_id": EqualFilter(func(b Battle) string { return b.SessionID }),
+		"outcome":    EqualFilter(func(b Battle) string { return b.Outcome }),
+		"from":       FromFilter(func(b Battle) time.Time { return b.CreatedAt }),
+		"to":         ToFilter(func(b Battle) time.Time { return b.CreatedAt }),
+	},
+	Sorts: map[string]func(a, b Battle) int{
+		"created_at":  func(a, b Battle) int { return a.CreatedAt.Compare(b.CreatedAt) },
+		"round":       func(a, b Battle) int { return cmp.Compare(a.Round, b.Round) },
+		"dice_thrown": func(a, b Battle) int { return cmp.Compare(a.DiceThrown, b.DiceThrown) },
+	},
+}
+
+var itemCollection = Collection[Item]{
+	Filters: map[string]Filter[Item]{
+		"effect_type":      EqualFilter(func(i Item) string { return i.EffectType }),
+		"min_effect_value": MinFilter(func(i Item) int { return i.EffectValue }),
+		"max_effect_value": MaxFilter(func(i Item) int { return i.EffectValue }),
+	},
+	Sorts: map[string]func(a, b Item) int{
+		"name":         func(a, b Item) int { return cmp.Compare(a.Name, b.Name) },
+		"effect_value": func(a, b Item) int { return cmp.Compare(a.EffectValue, b.EffectValue) },
+	},
+}
+
+func MinFilter[T any](field func(T) int) Filter[T] {
+	return func(value string) (func(T) bool, error) {
+		n, err := strconv.Atoi(value)
+		return func(record T) bool { return field(record) >= n }, err
+	}
+}
+
+func MaxFilter[T any](field func(T) int) Filter[T] {
+	return func(value string) (func(T) bool, error) {
+		n, err := strconv.Atoi(value)
+		return func(record T) bool { return field(record) <= n }, err
+	}
+}
+
+func AliveFilter[T any](life func(T) int) Filter[T] {
+	return func(value string) (func(T) bool, error) {
+		alive, err := strconv.ParseBool(value)
+		return func(record T) bool { return (life(record) > 0) == alive }, err
+	}
+}
+
+func EqualFilter[T any](field func(T) string) Filter[T] {
+	return func(value string) (func(T) bool, error) {
+		return func(record T) bool { return field(record) == value }, nil
+	}
+}
+
+func FromFilter[T any](field func(T) time.Time) Filter[T] {
+	return func(value string) (func(T) bool, error) {
+		from, err := time.Parse(time.RFC3339, value)
+		return func(record T) bool { return !field(record).Before(from) }, err
+	}
+}
+
+func ToFilter[T any](field func(T) time.Time) Filter[T] {
+	return func(value string) (func(T) bool, error) {
+		to, err := time.Parse(time.RFC3339, value)
+		return func(record T) bool { return field(record).Before(to) }, err
+	}
+}
+
+// List applies the filters, sorting and limit/offset pagination in the query
+// string to records. The total is the number of records matching the filters,
+// before pagination.
+func (c Collection[T]) List(query map[string][]string, records []T) (page []T, total int, message string) {
+	result := []T{}
+	var predicates []func(T) bool
+	for name, filter := range c.Filters {
+		value, ok := query[name]
+		if !ok {
+			continue
+		}
+		predicate, err := filter(value[0])
+		if err != nil {
+			return nil, 0, "Invalid value for " + name
+		}
+		predicates = append(predicates, predicate)
+	}
+	for _, record := range records {
+		if !slices.ContainsFunc(predicates, func(predicate func(T) bool) bool { return !predicate(record) }) {
+			result = append(result, record)
+		}
+	}
+
+	if sort, ok := query["sort"]; ok && sort[0] != "" {
+		var compares []func(a, b T) int
+		for _, field := range strings.Split(sort[0], ",") {
+			compare, ok := c.Sorts[strings.TrimPrefix(field, "-")]
+			if !ok {
+				return nil, 0, "Invalid sort field " + field
+			}
+			if strings.HasPrefix(field, "-") {
+				asc := compare
+				compare = func(a, b T) int { return asc(b, a) }
+			}
+			compares = append(compares, compare)
+		}
+		slices.SortStableFunc(result, func(a, b T) int {
+			for _, compare := range compares {
+				if order := compare(a, b); order != 0 {
+					return order
+				}
+			}
+			return 0
+		})
+	}
+
+	total = len(result)
+	offset := 0
+	if value, ok := query["offset"]; ok {
+		n, err := strconv.Atoi(value[0])
+		if err != nil || n < 0 {
+			return nil, 0, "Invalid value for offset"
+		}
+		offset = min(n, total)
+	}
+	// The limit is clamped before adding it to the offset, which would
+	// overflow for limits close to the largest int.
+	limit := total - offset
+	if value, ok := query["limit"]; ok {
+		n, err := strconv.Atoi(value[0])
+		if err != nil || n < 0 {
+			return nil, 0, "Invalid value for limit"
+		}
+		limit = min(n, limit)
+	}
+	return result[offset : offset+limit], total, ""
+}
+
+// WriteList encodes the requested page of records, with the total count of
+// matching records in the X-Total-Count header.
+func WriteList[T any](w http.ResponseWriter, r *http.Request, c Collection[T], records []T) {
+	w.Header().Set("Content-Type", "application/json")
+	page, total, message := c.List(r.URL.Query(), records)
+	if message != "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: message})
+		return
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		RequestLogger(r).Error("encode list response", "error", err)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCollectionListPagination(t *testing.T) {
+	c := Collection[int]{}
+	records := []int{1, 2, 3, 4}
+	for _, tt := range []struct {
+		offset, limit string
+		want          []int
+		message       string
+	}{
+		{want: []int{1, 2, 3, 4}},
+		{offset: "1", want: []int{2, 3, 4}},
+		{limit: "2", want: []int{1, 2}},
+		{offset: "3", limit: "5", want: []int{4}},
+		{offset: "9", limit: "2", want: []int{}},
+		{offset: "1", limit: "9223372036854775807", want: []int{2, 3, 4}},
+		{limit: "-1", message: "Invalid value for limit"},
+		{offset: "x", message: "Invalid value for offset"},
+	} {
+		query := map[string][]string{}
+		if tt.offset != "" {
+			query["offset"] = []string{tt.offset}
+		}
+		if tt.limit != "" {
+			query["limit"] = []string{tt.limit}
+		}
+		page, total, message := c.List(query, records)
+		if message != tt.message {
+			t.Errorf("offset %q limit %q: message %q, want %q", tt.offset, tt.limit, message, tt.message)
+			continue
+		}
+		if tt.message == "" && (!slices.Equal(page, tt.want) || total != len(records)) {
+			t.Errorf("offset %q limit %q: got %v of %d, want %v of %d", tt.offset, tt.limit, page, total, tt.want, len(records))
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const requestIDKey contextKey = "request_id"
+
+var logger = slog.Default()
+
+func NewLogger(level, format string) (*slog.Logger, error) {
+	var slogLevel slog.Level
+	if err := slogLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q, expected debug, info, warn or error", level)
+	}
+	options := &slog.HandlerOptions{Level: slogLevel}
+	switch strings.ToLower(format) {
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, options)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, options)), nil
+	}
+	return nil, fmt.Errorf("invalid log format %q, expected json or text", format)
+}
+
+// RequestLogger returns the logger annotated with the ID of the request.
+func RequestLogger(r *http.Request) *slog.Logger {
+	return ContextLogger(r.Context())
+}
+
+func ContextLogger(ctx context.Context) *slog.Logger {
+	if id := RequestIDFrom(ctx); id != "" {
+		return logger.With("request_id", id)
+	}
+	return logger
+}
+
+// RequestIDFrom returns the ID set by the RequestID middleware, empty when
+// there is none.
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// statusRecorder remembers the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// RequestID reuses the X-Request-ID header sent by the client or generates a
+// new ID, returning it in the response headers.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if id == "" || len(id) > 128 {
+			id = uuid.NewString()
+		}
+		w.Header().Set("X-Request-ID", id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey, id)))
+	})
+}
+
+// AccessLog logs every request with the mux pattern it matched, so requests
+// to /player/TheClip and /player/Other are grouped under the same route.
+func AccessLog(mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			_, route := mux.Handler(r)
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			RequestLogger(r).Info("request",
+				"method", r.Method,
+				"route", route,
+				"status", recorder.status,
+				"latency", time.Since(start),
+			)
+		})
+	}
+}
+
+// Chain wraps handler with middlewares, the first one being the outermost.
+func Chain(handler http.Handler, middlewares ...func(http.Handler) http.Handler) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A metric holds the values of one Prometheus counter or histogram for every
+// combination of its label values.
+type metric struct {
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+	mu      sync.Mutex
+	values  map[string]*metricValue
+}
+
+type metricValue struct {
+	labels []string
+	count  float64
+	sum    float64
+	counts []float64
+}
+
+var (
+	httpRequests = &metric{
+		name:   "http_requests_total",
+		help:   "HTTP requests by method, route and status.",
+		kind:   "counter",
+		labels: []string{"method", "route", "status"},
+	}
+	httpDuration = &metric{
+		name:    "http_request_duration_seconds",
+		help:    "HTTP request latency by route.",
+		kind:    "histogram",
+		labels:  []string{"route"},
+		buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5},
+	}
+	battlesStarted = &metric{
+		name: "rpg_battles_started_total",
+		help: "Battles started.",
+		kind: "counter",
+	}
+	battleRounds = &metric{
+		name: "rpg_battle_rounds_total",
+		help: "Battle rounds played.",
+		kind: "counter",
+	}
+	battleWins = &metric{
+		name:   "rpg_battle_wins_total",
+		help:   "Battles won by side.",
+		kind:   "counter",
+		labels: []string{"side"},
+	}
+	// diceThrown counts each face on its own, as the dice sides change with
+	// the balance while the buckets of a histogram cannot.
+	diceThrown = &metric{
+		name:   "rpg_dice_thrown_total",
+		help:   "Dice thrown in battle rounds by value.",
+		kind:   "counter",
+		labels: []string{"value"},
+	}
+	itemsCreated = &metric{
+		name:   "rpg_items_created_total",
+		help:   "Items created by effect type.",
+		kind:   "counter",
+		labels: []string{"effect_type"},
+	}
+)
+
+var metrics = []*metric{httpRequests, httpDuration, battlesStarted, battleRounds, battleWins, diceThrown, itemsCreated}
+
+func (m *metric) value(labels []string) *metricValue {
+	key := strings.Join(labels, "\x00")
+	if m.values == nil {
+		m.values = map[string]*metricValue{}
+	}
+	v, ok := m.values[key]
+	if !ok {
+		v = &metricValue{labels: labels, counts: make([]float64, len(m.buckets))}
+		m.values[key] = v
+	}
+	return v
+}
+
+func (m *metric) Inc(labels ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.value(labels).count++
+}
+
+func (m *metric) Observe(observation float64, labels ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v := m.value(labels)
+	v.count++
+	v.sum += observation
+	for i, bound := range m.buckets {
+		if observation <= bound {
+			v.counts[i]++
+		}
+	}
+}
+
+func formatLabels(names, values []string, extra ...string) string {
+	var pairs []string
+	for i, name := range names {
+		pairs = append(pairs, name+"="+strconv.Quote(values[i]))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+"="+strconv.Quote(extra[i+1]))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func (m *metric) write(b *strings.Builder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+	keys := make([]string, 0, len(m.values))
+	for key := range m.values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		v := m.values[key]
+		if m.kind == "counter" {
+			fmt.Fprintf(b, "%s%s %s\n", m.name, formatLabels(m.labels, v.labels), formatFloat(v.count))
+			continue
+		}
+		for i, bound := range m.buckets {
+			fmt.Fprintf(b, "%s_bucket%s %s\n", m.name, formatLabels(m.labels, v.labels, "le", formatFloat(bound)), formatFloat(v.counts[i]))
+		}
+		fmt.Fprintf(b, "%s_bucket%s %s\n", m.name, formatLabels(m.labels, v.labels, "le", "+Inf"), formatFloat(v.count))
+		fmt.Fprintf(b, "%s_sum%s %s\n", m.name, formatLabels(m.labels, v.labels), formatFloat(v.sum))
+		fmt.Fprintf(b, "%s_count%s %s\n", m.name, formatLabels(m.labels, v.labels), formatFloat(v.count))
+	}
+}
+
+func ObserveBattle(battle Battle) {
+	if battle.Round == 1 {
+		battlesStarted.Inc()
+	}
+	battleRounds.Inc()
+	diceThrown.Inc(strconv.Itoa(battle.DiceThrown))
+	switch battle.Outcome {
+	case SessionPlayerWon:
+		battleWins.Inc("player")
+	case SessionEnemyWon:
+		battleWins.Inc("enemy")
+	}
+}
+
+// Instrument counts requests and their latency by the mux pattern matched.
+func Instrument(mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			_, route := mux.Handler(r)
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			httpRequests.Inc(r.Method, route, strconv.Itoa(recorder.status))
+			httpDuration.Observe(time.Since(start).Seconds(), route)
+		})
+	}
+}
+
+func LoadMetrics(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	for _, m := range metrics {
+		m.write(&b)
+	}
+	alive, dead := 0, 0
+	for _, player := range game.Players() {
+		if player.Life > 0 {
+			alive++
+		} else {
+			dead++
+		}
+	}
+	fmt.Fprintf(&b, "# HELP rpg_players Players by state.\n# TYPE rpg_players gauge\n")
+	fmt.Fprintf(&b, "rpg_players{state=\"alive\"} %d\nrpg_players{state=\"dead\"} %d\n", alive, dead)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write([]byte(b.String()))
+}
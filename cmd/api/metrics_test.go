@@ -0,0 +1,21 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiceThrownCountsEveryValue(t *testing.T) {
+	diceThrown.values = nil
+	defer func() { diceThrown.values = nil }()
+	for _, dice := range []int{12, 3, 12} {
+		ObserveBattle(Battle{Round: 2, DiceThrown: dice})
+	}
+	var b strings.Builder
+	diceThrown.write(&b)
+	want := "# HELP rpg_dice_thrown_total Dice thrown in battle rounds by value.\n# TYPE rpg_dice_thrown_total counter\n" +
+		"rpg_dice_thrown_total{value=\"12\"} 2\nrpg_dice_thrown_total{value=\"3\"} 1\n"
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Uemerson/go-simple-rpg-api/graphql"
+)
+
+type Operation struct {
+	Method   string
+	Path     string
+	Tag      string
+	Summary  string
+	Auth     bool
+	Query    []string
+	Request  any
+	Response any
+	Status   int
+	Stream   bool
+	// Idempotent operations accept an Idempotency-Key header.
+	Idempotent bool
+}
+
+var nickname = []string{"nickname"}
+var listQuery = []string{"limit", "offset", "sort"}
+
+// apiOperations describes every route of the API. A test makes sure it stays
+// in sync with the routes registered by NewMux.
+var apiOperations = []Operation{
+	{Method: "POST", Path: "/register", Tag: "accounts", Summary: "Register an account", Request: Credentials{}, Response: Account{}},
+	{Method: "POST", Path: "/login", Tag: "accounts", Summary: "Log in and get access and refresh tokens", Request: Credentials{}, Response: TokenPair{}},
+	{Method: "POST", Path: "/token/refresh", Tag: "accounts", Summary: "Exchange a refresh token for new tokens", Request: RefreshRequest{}, Response: TokenPair{}},
+	{Method: "POST", Path: "/logout", Tag: "accounts", Summary: "Revoke the access and refresh tokens", Request: RefreshRequest{}, Status: http.StatusNoContent},
+
+	{Method: "POST", Path: "/player", Tag: "players", Summary: "Create a player", Auth: true, Request: PlayerRequest{}, Response: PlayerRequest{}, Idempotent: true},
+	{Method: "GET", Path: "/player", Tag: "players", Summary: "List players", Query: append([]string{"min_life", "max_life", "min_attack", "max_attack", "alive", "item_id"}, listQuery...), Response: []PlayerRequest{}},
+	{Method: "GET", Path: "/player/", Tag: "players", Summary: "Get a player", Query: nickname, Response: PlayerRequest{}},
+	{Method: "PUT", Path: "/player/", Tag: "players", Summary: "Replace a player", Auth: true, Query: nickname, Request: PlayerRequest{}, Response: PlayerRequest{}},
+	{Method: "DELETE", Path: "/player/", Tag: "players", Summary: "Delete a player", Auth: true, Query: nickname, Status: http.StatusNoContent},
+	{Method: "GET", Path: "/player/{nickname}/battles", Tag: "players", Summary: "List the battle rounds of a player", Response: []Battle{}},
+	{Method: "GET", Path: "/player/{nickname}/stats", Tag: "players", Summary: "Get the battle statistics of a player", Response: BattleStats{}},
+
+	{Method: "POST", Path: "/enemy", Tag: "enemies", Summary: "Spawn an enemy, optionally from a template", Auth: true, Request: Enemy{}, Response: Enemy{}, Idempotent: true},
+	{Method: "GET", Path: "/enemy", Tag: "enemies", Summary: "List enemies", Query: append([]string{"min_life", "max_life", "min_attack", "max_attack", "alive", "behavior"}, listQuery...), Response: []Enemy{}},
+	{Method: "GET", Path: "/enemy/", Tag: "enemies", Summary: "Get an enemy", Query: nickname, Response: Enemy{}},
+	{Method: "PUT", Path: "/enemy/", Tag: "enemies", Summary: "Replace an enemy, keeping its stats", Auth: true, Query: nickname, Request: Enemy{}, Response: Enemy{}},
+	{Method: "DELETE", Path: "/enemy/", Tag: "enemies", Summary: "Delete an enemy", Auth: true, Query: nickname, Status: http.StatusNoContent},
+	{Method: "GET", Path: "/enemy/{nickname}/battles", Tag: "enemies", Summary: "List the battle rounds of an enemy", Response: []Battle{}},
+	{Method: "GET", Path: "/enemy/{nickname}/stats", Tag: "enemies", Summary: "Get the battle statistics of an enemy", Response: BattleStats{}},
+
+	{Method: "POST", Path: "/battle", Tag: "battles", Summary: "Play a battle round", Auth: true, Request: BattleRequest{}, Response: Battle{}, Idempotent: true},
+	{Method: "GET", Path: "/battle", Tag: "battles", Summary: "List battle rounds", Query: append([]string{"player", "enemy", "session_id", "outcome", "from", "to"}, listQuery...), Response: []Battle{}},
+	{Method: "GET", Path: "/battle/{session}/events", Tag: "battles", Summary: "Stream the events of a battle as Server-Sent Events", Response: Event{}, Stream: true},
+	{Method: "GET", Path: "/events", Tag: "battles", Summary: "Stream the events of every battle as Server-Sent Events", Query: []string{"player", "enemy"}, Response: Event{}, Stream: true},
+	{Method: "GET", Path: "/leaderboard", Tag: "battles", Summary: "Get a leaderboard", Query: []string{"board", "window", "limit"}, Response: LeaderboardResponse{}},
+
+	{Method: "POST", Path: "/graphql", Tag: "graphql", Summary: "Run a GraphQL query over players, enemies, items and battles", Request: graphql.Request{}, Response: graphql.Response{}},
+	{Method: "GET", Path: "/graphql/schema", Tag: "graphql", Summary: "The GraphQL schema"},
+
+	{Method: "POST", Path: "/item", Tag: "items", Summary: "Create an item", Auth: true, Request: Item{}, Response: Item{}, Idempotent: true},
+	{Method: "GET", Path: "/item", Tag: "items", Summary: "List items", Query: append([]string{"effect_type", "min_effect_value", "max_effect_value"}, listQuery...), Response: []Item{}},
+
+	{Method: "POST", Path: "/admin/keys/rotate", Tag: "admin", Summary: "Rotate the token signing key", Auth: true, Response: RotatedKey{}},
+	{Method: "PUT", Path: "/admin/accounts/{username}/role", Tag: "admin", Summary: "Change the role of an account", Auth: true, Request: RoleRequest{}, Response: Account{}},
+	{Method: "POST", Path: "/admin/accounts/{username}/ban", Tag: "admin", Summary: "Ban an account", Auth: true, Response: Account{}},
+	{Method: "DELETE", Path: "/admin/accounts/{username}/ban", Tag: "admin", Summary: "Lift the ban of an account", Auth: true, Response: Account{}},
+	{Method: "POST", Path: "/admin/templates", Tag: "admin", Summary: "Create an enemy template", Auth: true, Request: EnemyTemplate{}, Response: EnemyTemplate{}},
+	{Method: "GET", Path: "/admin/templates", Tag: "admin", Summary: "List enemy templates", Auth: true, Response: []EnemyTemplate{}},
+	{Method: "PUT", Path: "/admin/players/{nickname}/stats", Tag: "admin", Summary: "Adjust the stats of any player", Auth: true, Request: StatsAdjustment{}, Response: PlayerRequest{}},
+	{Method: "POST", Path: "/admin/battles/reset", Tag: "admin", Summary: "Delete every battle and leaderboard", Auth: true, Status: http.StatusNoContent},
+	{Method: "GET", Path: "/admin/balance", Tag: "admin", Summary: "Get the live game balance", Auth: true, Response: Balance{}},
+	{Method: "GET", Path: "/admin/audit", Tag: "admin", Summary: "Query the audit log", Auth: true, Query: append([]string{"actor", "action", "target", "from", "to"}, listQuery...), Response: []AuditEntry{}},
+
+	{Method: "GET", Path: "/metrics", Tag: "operations", Summary: "Prometheus metrics"},
+	{Method: "GET", Path: "/healthz", Tag: "operations", Summary: "Liveness probe", Response: PlayerResponse{}},
+	{Method: "GET", Path: "/readyz", Tag: "operations", Summary: "Readiness probe", Response: PlayerResponse{}},
+	{Method: "GET", Path: "/openapi.json", Tag: "operations", Summary: "This OpenAPI document"},
+	{Method: "GET", Path: "/docs", Tag: "operations", Summary: "API documentation page"},
+}
+
+// RouteMux is a ServeMux remembering the patterns registered on it.
+type RouteMux struct {
+	*http.ServeMux
+	Patterns []string
+}
+
+func NewRouteMux() *RouteMux {
+	return &RouteMux{ServeMux: http.NewServeMux()}
+}
+
+func (m *RouteMux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	m.Patterns = append(m.Patterns, pattern)
+	m.ServeMux.HandleFunc(pattern, handler)
+}
+
+// schemas collects the component schemas of the Go types used in the spec.
+type schemas map[string]any
+
+func (s schemas) of(t reflect.Type) map[string]any {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	if t == reflect.TypeOf(Duration{}) {
+		return map[string]any{"type": "string", "example": "10s"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return s.of(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": s.of(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.of(t.Elem())}
+	case reflect.Struct:
+		if _, ok := s[t.Name()]; !ok {
+			properties := map[string]any{}
+			s[t.Name()] = map[string]any{"type": "object", "properties": properties}
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+				if !field.IsExported() || name == "-" {
+					continue
+				}
+				properties[name] = s.of(field.Type)
+			}
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	}
+	return map[string]any{}
+}
+
+func content(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+func OpenAPISpec(operations []Operation) map[string]any {
+	components := schemas{}
+	errorResponse := map[string]any{"description": "Error", "content": content(components.of(reflect.TypeOf(ErrorResponse{})))}
+	paths := map[string]any{}
+	for _, op := range operations {
+		status := op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success := map[string]any{"description": http.StatusText(status)}
+		if op.Stream {
+			success["content"] = map[string]any{"text/event-stream": map[string]any{"schema": components.of(reflect.TypeOf(op.Response))}}
+		} else if op.Response != nil {
+			success["content"] = content(components.of(reflect.TypeOf(op.Response)))
+		}
+		operation := map[string]any{
+			"tags":      []string{op.Tag},
+			"summary":   op.Summary,
+			"responses": map[string]any{fmt.Sprint(status): success, "default": errorResponse},
+		}
+		var parameters []any
+		for _, name := range []string{"nickname", "username", "session"} {
+			if strings.Contains(op.Path, "{"+name+"}") {
+				parameters = append(parameters, map[string]any{"name": name, "in": "path", "required": true, "schema": map[string]any{"type": "string"}})
+			}
+		}
+		for _, name := range op.Query {
+			parameters = append(parameters, map[string]any{"name": name, "in": "query", "required": name == "nickname", "schema": map[string]any{"type": "string"}})
+		}
+		if op.Idempotent {
+			parameters = append(parameters, map[string]any{"name": "Idempotency-Key", "in": "header", "schema": map[string]any{"type": "string", "maxLength": 255}})
+		}
+		if parameters != nil {
+			operation["parameters"] = parameters
+		}
+		if op.Request != nil {
+			operation["requestBody"] = map[string]any{"required": true, "content": content(components.of(reflect.TypeOf(op.Request)))}
+		}
+		if op.Auth {
+			operation["security"] = []any{map[string]any{"bearer": []string{}}}
+		}
+		if _, ok := paths[op.Path]; !ok {
+			paths[op.Path] = map[string]any{}
+		}
+		paths[op.Path].(map[string]any)[strings.ToLower(op.Method)] = operation
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "go-simple-rpg-api",
+			"description": "A simple API for a turn-based RPG game.",
+			"version":     "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": components,
+			"securitySchemes": map[string]any{
+				"bearer": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}
+}
+
+func LoadOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(OpenAPISpec(apiOperations))
+}
+
+// docsPage lists the operations of the API. It is rendered by the server
+// rather than by a documentation viewer from a CDN, so the page runs no
+// third-party code, and tools wanting the full schemas read /openapi.json.
+var docsPage = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>go-simple-rpg-api</title>
+<meta charset="utf-8">
+<style>
+body { font-family: sans-serif; margin: 2em; }
+td, th { padding: 0.3em 1em 0.3em 0; text-align: left; vertical-align: top; }
+code { font-size: 1.1em; }
+</style>
+</head>
+<body>
+<h1>go-simple-rpg-api</h1>
+<p>The request and response schemas are in the <a href="/openapi.json">OpenAPI specification</a>.</p>
+<table>
+<tr><th>Tag</th><th>Method</th><th>Path</th><th>Summary</th><th>Authentication</th></tr>
+{{range .}}<tr><td>{{.Tag}}</td><td><code>{{.Method}}</code></td><td><code>{{.Path}}</code></td><td>{{.Summary}}</td><td>{{if .Auth}}Bearer token{{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+func LoadDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	if err := docsPage.Execute(w, apiOperations); err != nil {
+		RequestLogger(r).Error("cannot render the docs page", "error", err)
+	}
+}
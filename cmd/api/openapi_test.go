@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSpecMatchesRoutes(t *testing.T) {
+	if err := CheckSpec(NewMux(&Store{}), apiOperations); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDocsListsOperationsWithoutScripts(t *testing.T) {
+	w := httptest.NewRecorder()
+	LoadDocs(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	body := w.Body.String()
+	if !strings.Contains(body, "<code>/player/{nickname}/battles</code>") || !strings.Contains(body, "Create a player") {
+		t.Errorf("docs page misses the operations:\n%s", body)
+	}
+	if strings.Contains(body, "<script") || !strings.Contains(w.Header().Get("Content-Security-Policy"), "default-src 'none'") {
+		t.Errorf("docs page may run scripts:\n%s", body)
+	}
+}
+
+func TestCheckSpecReportsDifferences(t *testing.T) {
+	mux := NewRouteMux()
+	mux.HandleFunc("GET /served", Healthz)
+	mux.HandleFunc("GET /undocumented", Healthz)
+	err := CheckSpec(mux, []Operation{
+		{Method: "GET", Path: "/served"},
+		{Method: "GET", Path: "/missing"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "/missing is in the OpenAPI spec") || !strings.Contains(err.Error(), "GET /undocumented is registered") {
+		t.Errorf("error %v, want /missing and /undocumented reported", err)
+	}
+}
+
+// CheckSpec fails when an operation of the spec is not served by the mux or
+// when a pattern registered on the mux serves no operation of the spec.
+func CheckSpec(mux *RouteMux, operations []Operation) error {
+	var errs []error
+	served := map[string]bool{}
+	for _, op := range operations {
+		path := strings.NewReplacer("{nickname}", "x", "{username}", "x").Replace(op.Path)
+		request, err := http.NewRequest(op.Method, path, nil)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		_, pattern := mux.Handler(request)
+		if pattern == "" {
+			errs = append(errs, fmt.Errorf("%s %s is in the OpenAPI spec but not registered on the mux", op.Method, op.Path))
+			continue
+		}
+		served[pattern] = true
+	}
+	for _, pattern := range mux.Patterns {
+		if !served[pattern] {
+			errs = append(errs, fmt.Errorf("%s is registered on the mux but missing from the OpenAPI spec", pattern))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A RateLimit refills Rate tokens per second into buckets holding at most
+// Burst tokens, each request taking one token.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type RateLimiter struct {
+	Limit     RateLimit
+	Now       func() time.Time
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+var rateLimiters = map[string]*RateLimiter{}
+
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	return &RateLimiter{Limit: limit, Now: time.Now, buckets: map[string]*bucket{}}
+}
+
+// Allow takes a token from the bucket of key, returning how long to wait for
+// the next token when the bucket is empty.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.Now()
+	if now.Sub(l.lastSweep) > time.Minute {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.Limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(l.Limit.Burst), b.tokens+now.Sub(b.last).Seconds()*l.Limit.Rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.Limit.Rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep drops the buckets that have refilled since they were last used, as
+// they are no different from the full bucket a new client gets.
+func (l *RateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.Limit.Rate >= float64(l.Limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// ParseRateLimits reads limits written as "POST /battle=2:5,POST /enemy=0.5:5",
+// the rate in requests per second followed by the burst.
+func ParseRateLimits(value string) (map[string]RateLimit, error) {
+	limits := map[string]RateLimit{}
+	for _, entry := range strings.Split(value, ",") {
+		route, limit, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		rate, burst, ok2 := strings.Cut(limit, ":")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("invalid rate limit %q, expected route=rate:burst", entry)
+		}
+		r, err := strconv.ParseFloat(rate, 64)
+		if err != nil || r <= 0 {
+			return nil, fmt.Errorf("invalid rate in %q", entry)
+		}
+		b, err := strconv.Atoi(burst)
+		if err != nil || b < 1 {
+			return nil, fmt.Errorf("invalid burst in %q", entry)
+		}
+		limits[route] = RateLimit{Rate: r, Burst: b}
+	}
+	return limits, nil
+}
+
+func InitRateLimiters(limits RateLimits) {
+	for route, limit := range limits {
+		rateLimiters[route] = NewRateLimiter(limit)
+	}
+}
+
+// clientKey identifies the caller by account when it sends a valid access
+// token and by IP address otherwise.
+func clientKey(r *http.Request) string {
+	if claims, err := tokens.Verify(bearerToken(r), AccessToken); err == nil {
+		return "account:" + claims.Subject
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+func writeTooManyRequests(w http.ResponseWriter, r *http.Request, wait time.Duration, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(ErrorResponse{Message: message, RequestID: RequestIDFrom(r.Context())})
+}
+
+func RateLimited(route string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if limiter, ok := rateLimiters[route]; ok {
+			if allowed, wait := limiter.Allow(clientKey(r)); !allowed {
+				writeTooManyRequests(w, r, wait, "Too many requests")
+				return
+			}
+		}
+		next(w, r)
+	}
+}
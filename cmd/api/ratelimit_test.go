@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterDropsRefilledBuckets(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(RateLimit{Rate: 1, Burst: 2})
+	limiter.Now = func() time.Time { return now }
+
+	limiter.Allow("idle")
+	limiter.Allow("busy")
+	limiter.Allow("busy")
+	if allowed, wait := limiter.Allow("busy"); allowed || wait != time.Second {
+		t.Fatalf("third request allowed %t, wait %s; want refused for 1s", allowed, wait)
+	}
+
+	// Minutes later both buckets have refilled and are swept.
+	now = now.Add(2 * time.Minute)
+	limiter.Allow("recent")
+	limiter.Allow("recent")
+	if len(limiter.buckets) != 1 {
+		t.Fatalf("%d buckets kept, want only the recent one", len(limiter.buckets))
+	}
+	now = now.Add(90 * time.Second)
+	limiter.Allow("other")
+	if _, ok := limiter.buckets["recent"]; ok {
+		t.Errorf("refilled bucket kept")
+	}
+	if allowed, _ := limiter.Allow("busy"); !allowed {
+		t.Errorf("swept client refused")
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import "net/http"
+
+// NewMux registers every route of the API, with the readiness probe checking
+// the given store.
+func NewMux(store *Store) *RouteMux {
+	mux := NewRouteMux()
+
+	mux.HandleFunc("POST /register", RateLimited("POST /register", Register))
+	mux.HandleFunc("POST /login", RateLimited("POST /login", Login))
+	mux.HandleFunc("POST /token/refresh", RefreshTokens)
+	mux.HandleFunc("POST /logout", Logout)
+
+	mux.HandleFunc("POST /admin/keys/rotate", RequireRole(RoleAdmin, RotateSigningKey))
+	mux.HandleFunc("PUT /admin/accounts/{username}/role", RequireRole(RoleAdmin, SetAccountRole))
+	mux.HandleFunc("POST /admin/accounts/{username}/ban", RequireRole(RoleGameMaster, SetAccountBan(true)))
+	mux.HandleFunc("DELETE /admin/accounts/{username}/ban", RequireRole(RoleGameMaster, SetAccountBan(false)))
+	mux.HandleFunc("POST /admin/templates", RequireRole(RoleGameMaster, AddEnemyTemplate))
+	mux.HandleFunc("GET /admin/templates", RequireRole(RoleGameMaster, LoadEnemyTemplates))
+	mux.HandleFunc("PUT /admin/players/{nickname}/stats", RequireRole(RoleGameMaster, AdjustPlayerStats))
+	mux.HandleFunc("POST /admin/battles/reset", RequireRole(RoleGameMaster, ResetBattles))
+	mux.HandleFunc("GET /admin/balance", RequireRole(RoleGameMaster, LoadCurrentBalance))
+	mux.HandleFunc("GET /admin/audit", RequireRole(RoleGameMaster, LoadAuditLog))
+
+	mux.HandleFunc("/player", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			RateLimited("POST /player", Authenticated(Idempotent("POST /player", AddPlayer)))(w, r)
+		case http.MethodGet:
+			LoadPlayers(w, r)
+		}
+	})
+
+	mux.HandleFunc("/player/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			Authenticated(DeletePlayer)(w, r)
+		case http.MethodGet:
+			LoadPlayerByNickname(w, r)
+		case http.MethodPut:
+			Authenticated(SavePlayer)(w, r)
+		}
+	})
+
+	mux.HandleFunc("GET /player/{nickname}/battles", LoadPlayerBattles)
+	mux.HandleFunc("GET /player/{nickname}/stats", LoadPlayerStats)
+
+	mux.HandleFunc("/enemy", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			RateLimited("POST /enemy", RequireRole(RoleGameMaster, Idempotent("POST /enemy", AddEnemy)))(w, r)
+		case http.MethodGet:
+			LoadEnemies(w, r)
+		}
+	})
+
+	mux.HandleFunc("/enemy/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			RequireRole(RoleGameMaster, DeleteEnemy)(w, r)
+		case http.MethodGet:
+			LoadEnemyByNickname(w, r)
+		case http.MethodPut:
+			RequireRole(RoleGameMaster, UpdateEnemy)(w, r)
+		}
+	})
+
+	mux.HandleFunc("GET /enemy/{nickname}/battles", LoadEnemyBattles)
+	mux.HandleFunc("GET /enemy/{nickname}/stats", LoadEnemyStats)
+
+	mux.HandleFunc("/battle", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			RateLimited("POST /battle", Authenticated(Idempotent("POST /battle", CreateBattle)))(w, r)
+		case http.MethodGet:
+			LoadBattles(w, r)
+		}
+	})
+
+	mux.HandleFunc("GET /battle/{session}/events", StreamBattleEvents)
+	mux.HandleFunc("GET /events", StreamEvents)
+
+	mux.HandleFunc("/item", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			RateLimited("POST /item", RequireRole(RoleGameMaster, Idempotent("POST /item", AddItem)))(w, r)
+		case http.MethodGet:
+			LoadItems(w, r)
+		}
+	})
+
+	mux.HandleFunc("GET /leaderboard", LoadLeaderboard)
+	mux.HandleFunc("POST /graphql", GraphQL)
+	mux.HandleFunc("GET /graphql/schema", LoadGraphQLSchema)
+	mux.HandleFunc("GET /metrics", LoadMetrics)
+	mux.HandleFunc("GET /healthz", Healthz)
+	mux.HandleFunc("GET /readyz", Readyz(store))
+	mux.HandleFunc("GET /openapi.json", LoadOpenAPISpec)
+	mux.HandleFunc("GET /docs", LoadDocs)
+	return mux
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+	"github.com/google/uuid"
+)
+
+// A GameError is a game operation rejected by the rules. The HTTP handlers
+// answer with Status and the gRPC services with the matching code.
+type GameError struct {
+	Status     int
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *GameError) Error() string {
+	return e.Message
+}
+
+func gameError(status int, message string) *GameError {
+	return &GameError{Status: status, Message: message}
+}
+
+// ErrorResponse is the JSON error body. RequestID matches the X-Request-ID
+// response header, for looking the request up in the logs.
+type ErrorResponse struct {
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// writeError writes err as the JSON error body, hiding the message of
+// errors that are not game errors.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var gameErr *GameError
+	if !errors.As(err, &gameErr) {
+		RequestLogger(r).Error("request failed", "error", err)
+		gameErr = gameError(http.StatusInternalServerError, "Internal Server Error")
+	}
+	if gameErr.Status == http.StatusTooManyRequests {
+		writeTooManyRequests(w, r, gameErr.RetryAfter, gameErr.Message)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(gameErr.Status)
+	json.NewEncoder(w).Encode(ErrorResponse{Message: gameErr.Message, RequestID: RequestIDFrom(r.Context())})
+}
+
+// GameService holds the game logic shared by the HTTP handlers and the
+// gRPC services. Its methods are named after the HTTP handlers and expect
+// the caller to be authorized for the route already; the account in the
+// context is the owner of new players and the actor of the audit log.
+//
+// The game state (players, enemies, items, battles, sessions, templates,
+// leaderboards and accounts) is only read or written with mu held, through
+// the methods or View and Update. Functions documented as expecting the lock
+// must not take it again.
+type GameService struct {
+	mu sync.RWMutex
+}
+
+var game = &GameService{}
+
+// View runs fn with the game state locked for reading.
+func (g *GameService) View(fn func()) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	fn()
+}
+
+// Update runs fn with the game state locked for writing.
+func (g *GameService) Update(fn func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	fn()
+}
+
+// Players returns a copy of the players.
+func (g *GameService) Players() []PlayerRequest {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return slices.Clone(players)
+}
+
+// Enemies returns a copy of the enemies.
+func (g *GameService) Enemies() []Enemy {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return slices.Clone(enemies)
+}
+
+// Items returns a copy of the items.
+func (g *GameService) Items() []Item {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return slices.Clone(items)
+}
+
+// Battles returns a copy of the battle rounds.
+func (g *GameService) Battles() []Battle {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return slices.Clone(battles)
+}
+
+// playerIndex is the index of the player in players, -1 when there is none.
+// It expects the lock to be held.
+func playerIndex(nickname string) int {
+	return slices.IndexFunc(players, func(p PlayerRequest) bool { return p.Nickname == nickname })
+}
+
+// enemyIndex is the index of the enemy in enemies, -1 when there is none.
+// It expects the lock to be held.
+func enemyIndex(nickname string) int {
+	return slices.IndexFunc(enemies, func(e Enemy) bool { return e.Nickname == nickname })
+}
+
+// validatePlayer checks the nickname and the stats of a player against the
+// configured ranges.
+func validatePlayer(player PlayerRequest) error {
+	if player.Nickname == "" {
+		return gameError(http.StatusBadRequest, "Player nickname is required")
+	}
+	if !config.Game.PlayerAttack.Contains(player.Attack) {
+		return gameError(http.StatusBadRequest, "Player attack must be between "+config.Game.PlayerAttack.Bounds())
+	}
+	if !config.Game.PlayerLife.Contains(player.Life) {
+		return gameError(http.StatusBadRequest, "Player life must be between "+config.Game.PlayerLife.Bounds())
+	}
+	if !config.Game.PlayerDefense.Contains(player.Defense) {
+		return gameError(http.StatusBadRequest, "Player defense must be between "+config.Game.PlayerDefense.Bounds())
+	}
+	if !config.Game.PlayerSpeed.Contains(player.Speed) {
+		return gameError(http.StatusBadRequest, "Player speed must be between "+config.Game.PlayerSpeed.Bounds())
+	}
+	return nil
+}
+
+func (g *GameService) AddPlayer(ctx context.Context, playerRequest PlayerRequest) (PlayerRequest, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err := validatePlayer(playerRequest); err != nil {
+		return PlayerRequest{}, err
+	}
+	if playerIndex(playerRequest.Nickname) >= 0 {
+		return PlayerRequest{}, gameError(http.StatusBadRequest, "Player nickname already exists")
+	}
+	playerRequest.Owner = AccountFrom(ctx).Username
+	players = append(players, playerRequest)
+	RecordAudit(ctx, "create_player", playerRequest.Nickname, nil, playerRequest)
+	return playerRequest, nil
+}
+
+func (g *GameService) LoadPlayerByNickname(nickname string) (PlayerRequest, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, player := range players {
+		if player.Nickname == nickname {
+			return player, nil
+		}
+	}
+	return PlayerRequest{}, gameError(http.StatusNotFound, "Player nickname not found")
+}
+
+// SavePlayer replaces the player, keeping its owner. Only game masters may
+// change its stats; the other accounts must send them unchanged.
+func (g *GameService) SavePlayer(ctx context.Context, nickname string, playerRequest PlayerRequest) (PlayerRequest, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	i := playerIndex(nickname)
+	if i < 0 {
+		return PlayerRequest{}, gameError(http.StatusNotFound, "Player not found")
+	}
+	player := players[i]
+	account := AccountFrom(ctx)
+	if !CanControl(account, player) {
+		return PlayerRequest{}, gameError(http.StatusForbidden, "Player belongs to another account")
+	}
+	if !HasRole(account, RoleGameMaster) && (playerRequest.Life != player.Life || playerRequest.Attack != player.Attack ||
+		playerRequest.Defense != player.Defense || playerRequest.Speed != player.Speed) {
+		return PlayerRequest{}, gameError(http.StatusForbidden, "Only game masters can change player stats")
+	}
+	if err := validatePlayer(playerRequest); err != nil {
+		return PlayerRequest{}, err
+	}
+	if playerRequest.Nickname != nickname && playerIndex(playerRequest.Nickname) >= 0 {
+		return PlayerRequest{}, gameError(http.StatusBadRequest, "Player nickname already exists")
+	}
+	playerRequest.Owner = player.Owner
+	players[i] = playerRequest
+	RecordAudit(ctx, "update_player", nickname, player, playerRequest)
+	return playerRequest, nil
+}
+
+func (g *GameService) DeletePlayer(ctx context.Context, nickname string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, player := range players {
+		if player.Nickname == nickname {
+			if !CanControl(AccountFrom(ctx), player) {
+				return gameError(http.StatusForbidden, "Player belongs to another account")
+			}
+			players = append(players[:i], players[i+1:]...)
+			RecordAudit(ctx, "delete_player", nickname, player, nil)
+			return nil
+		}
+	}
+	return gameError(http.StatusNotFound, "Player nickname not found")
+}
+
+// AddEnemy rolls the life, attack and speed of the enemy from its template,
+// or from the balance ranges when it has none.
+func (g *GameService) AddEnemy(ctx context.Context, enemyRequest Enemy) (Enemy, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, enemy := range enemies {
+		if enemy.Nickname == enemyRequest.Nickname {
+			return Enemy{}, gameError(http.StatusBadRequest, "Enemy nickname already exists")
+		}
+	}
+	balance := CurrentBalance()
+	template := EnemyTemplate{
+		MinLife:   balance.EnemyLife.Min,
+		MaxLife:   balance.EnemyLife.Max,
+		MinAttack: balance.EnemyAttack.Min,
+		MaxAttack: balance.EnemyAttack.Max,
+	}
+	if enemyRequest.Template != "" {
+		found := FindTemplate(enemyRequest.Template)
+		if found == nil {
+			return Enemy{}, gameError(http.StatusBadRequest, "Enemy template not found")
+		}
+		template = *found
+		enemyRequest.Defense = template.Defense
+		if enemyRequest.Behavior == "" {
+			enemyRequest.Behavior = template.Behavior
+		}
+	}
+	if enemyRequest.Behavior == "" {
+		enemyRequest.Behavior = "aggressive"
+	}
+	if _, ok := engine.Behaviors[enemyRequest.Behavior]; !ok {
+		return Enemy{}, gameError(http.StatusBadRequest, "Enemy behavior must be aggressive, defensive, healer, fleeing or random")
+	}
+	stats := balance.SpawnEnemy(Range{Min: template.MinLife, Max: template.MaxLife}, Range{Min: template.MinAttack, Max: template.MaxAttack}, rng)
+	enemyRequest.Life, enemyRequest.Attack, enemyRequest.Speed = stats.Life, stats.Attack, stats.Speed
+	enemies = append(enemies, enemyRequest)
+	RecordAudit(ctx, "create_enemy", enemyRequest.Nickname, nil, enemyRequest)
+	return enemyRequest, nil
+}
+
+func (g *GameService) LoadEnemyByNickname(nickname string) (Enemy, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, enemy := range enemies {
+		if enemy.Nickname == nickname {
+			return enemy, nil
+		}
+	}
+	return Enemy{}, gameError(http.StatusNotFound, "Enemy nickname not found")
+}
+
+// UpdateEnemy replaces the enemy, keeping its rolled stats and, when none
+// is given, its behavior.
+func (g *GameService) UpdateEnemy(ctx context.Context, nickname string, enemyRequest Enemy) (Enemy, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := engine.Behaviors[enemyRequest.Behavior]; enemyRequest.Behavior != "" && !ok {
+		return Enemy{}, gameError(http.StatusBadRequest, "Enemy behavior must be aggressive, defensive, healer, fleeing or random")
+	}
+	for i, enemy := range enemies {
+		if enemy.Nickname == nickname {
+			enemyRequest.Life = enemy.Life
+			enemyRequest.Attack = enemy.Attack
+			enemyRequest.Speed = enemy.Speed
+			if enemyRequest.Behavior == "" {
+				enemyRequest.Behavior = enemy.Behavior
+			}
+			enemies[i] = enemyRequest
+			RecordAudit(ctx, "update_enemy", nickname, enemy, enemyRequest)
+			return enemyRequest, nil
+		}
+	}
+	return Enemy{}, gameError(http.StatusNotFound, "Enemy not found")
+}
+
+func (g *GameService) DeleteEnemy(ctx context.Context, nickname string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, enemy := range enemies {
+		if enemy.Nickname == nickname {
+			enemies = append(enemies[:i], enemies[i+1:]...)
+			RecordAudit(ctx, "delete_enemy", nickname, enemy, nil)
+			return nil
+		}
+	}
+	return gameError(http.StatusNotFound, "Enemy nickname not found")
+}
+
+// AddItem creates the item. Its effect type is checked before it is used as
+// a metric label.
+func (g *GameService) AddItem(ctx context.Context, item Item) (Item, error) {
+	if !engine.ValidEffect(item.EffectType) {
+		return Item{}, gameError(http.StatusBadRequest, "Item effect type must be attack, defense or life")
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	item.ID = uuid.NewString()
+	items = append(items, item)
+	RecordAudit(ctx, "create_item", item.ID, nil, item)
+	itemsCreated.Inc(item.EffectType)
+	return item, nil
+}
+
+// CreateBattle plays one round between the player and the enemy, starting
+// a battle session when they are not fighting yet.
+func (g *GameService) CreateBattle(ctx context.Context, battleRequest BattleRequest) (Battle, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if battleRequest.Action == "" {
+		battleRequest.Action = ActionAttack
+	}
+	if !engine.PlayerActions[battleRequest.Action] {
+		return Battle{}, gameError(http.StatusBadRequest, "Invalid action "+string(battleRequest.Action)+", must be attack, defend, heal, flee or wait")
+	}
+	i, j := playerIndex(battleRequest.Player), enemyIndex(battleRequest.Enemy)
+	if i < 0 || j < 0 {
+		return Battle{}, gameError(http.StatusNotFound, "Player or Enemy not found")
+	}
+	player, enemy := players[i], enemies[j]
+	if !CanControl(AccountFrom(ctx), player) {
+		return Battle{}, gameError(http.StatusForbidden, "Player belongs to another account")
+	}
+	if player.Life <= 0 || enemy.Life <= 0 {
+		return Battle{}, gameError(http.StatusBadRequest, "One of the combatants is dead, battle cannot proceed")
+	}
+	if wait := CooldownLeft(player.Nickname); wait > 0 {
+		return Battle{}, &GameError{Status: http.StatusTooManyRequests, Message: "Player is resting, battle cannot proceed yet", RetryAfter: wait}
+	}
+	k := findOrStartSession(player.Nickname, enemy.Nickname)
+	session := sessions[k]
+	before := map[string]int{"player_life": player.Life, "enemy_life": enemy.Life}
+	battle, err := ResolveRound(&session, &player, &enemy, battleRequest.Action)
+	if err != nil {
+		return Battle{}, err
+	}
+	StartCooldown(player.Nickname)
+	players[i], enemies[j], sessions[k] = player, enemy, session
+	RecordAudit(ctx, "battle_round", battle.SessionID, before, map[string]int{"player_life": player.Life, "enemy_life": enemy.Life})
+	battles = append(battles, battle)
+	RecordLeaderboards(battle)
+	ObserveBattle(battle)
+	PublishRound(battle, player, enemy)
+	return battle, nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAddItemChecksTheEffectType(t *testing.T) {
+	defer func() { items = nil }()
+	for _, tt := range []struct {
+		effectType string
+		want       int
+	}{
+		{"attack", 0},
+		{"defense", 0},
+		{"life", 0},
+		{"", http.StatusBadRequest},
+		{"attack\"} 1\nrpg_fake{", http.StatusBadRequest},
+	} {
+		_, err := game.AddItem(context.Background(), Item{Name: "Thing", EffectType: tt.effectType, EffectValue: 1})
+		var gameErr *GameError
+		switch {
+		case tt.want == 0 && err != nil:
+			t.Errorf("%q: %v", tt.effectType, err)
+		case tt.want != 0 && (!errors.As(err, &gameErr) || gameErr.Status != tt.want):
+			t.Errorf("%q: error %v, want status %d", tt.effectType, err, tt.want)
+		}
+	}
+	if len(items) != 3 {
+		t.Errorf("%d items created, want 3", len(items))
+	}
+}
+
+func TestSavePlayerChecksTheChanges(t *testing.T) {
+	players = []PlayerRequest{{Nickname: "A", Life: 10, Attack: 5, Owner: "alice"}, {Nickname: "B", Life: 10, Attack: 5, Owner: "alice"}}
+	defer func() { players = nil }()
+	owner := context.WithValue(context.Background(), accountKey, &Account{Username: "alice", Role: RolePlayer})
+	admin := context.WithValue(context.Background(), accountKey, &Account{Username: "root", Role: RoleAdmin})
+	for _, tt := range []struct {
+		name   string
+		ctx    context.Context
+		player PlayerRequest
+		want   int
+	}{
+		{"stats changed by the owner", owner, PlayerRequest{Nickname: "A", Life: 20, Attack: 5}, http.StatusForbidden},
+		{"life out of range", admin, PlayerRequest{Nickname: "A", Life: 100000, Attack: 5}, http.StatusBadRequest},
+		{"negative defense", admin, PlayerRequest{Nickname: "A", Life: 10, Attack: 5, Defense: -1}, http.StatusBadRequest},
+		{"empty nickname", owner, PlayerRequest{Life: 10, Attack: 5}, http.StatusBadRequest},
+		{"nickname taken", owner, PlayerRequest{Nickname: "B", Life: 10, Attack: 5}, http.StatusBadRequest},
+		{"rename", owner, PlayerRequest{Nickname: "C", Life: 10, Attack: 5}, 0},
+	} {
+		_, err := game.SavePlayer(tt.ctx, "A", tt.player)
+		var gameErr *GameError
+		switch {
+		case tt.want == 0 && err != nil:
+			t.Errorf("%s: %v", tt.name, err)
+		case tt.want != 0 && (!errors.As(err, &gameErr) || gameErr.Status != tt.want):
+			t.Errorf("%s: error %v, want status %d", tt.name, err, tt.want)
+		}
+	}
+	if players[0].Nickname != "C" || players[0].Life != 10 || players[1].Nickname != "B" {
+		t.Errorf("players %+v", players)
+	}
+}
+
+func TestAdjustPlayerStatsChecksTheRanges(t *testing.T) {
+	players = []PlayerRequest{{Nickname: "A", Life: 10, Attack: 5}}
+	defer func() { players = nil }()
+	for _, tt := range []struct {
+		body string
+		want int
+	}{
+		{`{"life": -5}`, http.StatusBadRequest},
+		{`{"attack": 100000}`, http.StatusBadRequest},
+		{`{"speed": -1}`, http.StatusBadRequest},
+		{`{"life": 20, "defense": 2}`, http.StatusOK},
+	} {
+		r := httptest.NewRequest(http.MethodPut, "/admin/players/A/stats", strings.NewReader(tt.body))
+		r.SetPathValue("nickname", "A")
+		w := httptest.NewRecorder()
+		AdjustPlayerStats(w, r)
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d: %s", tt.body, w.Code, tt.want, w.Body)
+		}
+	}
+	if players[0].Life != 20 || players[0].Attack != 5 || players[0].Defense != 2 {
+		t.Errorf("player %+v", players[0])
+	}
+}
+
+func TestAddPlayerChecksTheStats(t *testing.T) {
+	defer func() { players = nil }()
+	ctx := context.WithValue(context.Background(), accountKey, &Account{Username: "alice", Role: RolePlayer})
+	for _, tt := range []struct {
+		player PlayerRequest
+		want   string
+	}{
+		{PlayerRequest{Life: 10, Attack: 5}, "Player nickname is required"},
+		{PlayerRequest{Nickname: "A", Life: 10, Attack: 5, Defense: 999999}, "Player defense must be between 0 and 10"},
+		{PlayerRequest{Nickname: "A", Life: 10, Attack: 5, Speed: -1}, "Player speed must be between 0 and 10"},
+		{PlayerRequest{Nickname: "A", Life: 10, Attack: 5, Defense: 10, Speed: 10}, ""},
+	} {
+		_, err := game.AddPlayer(ctx, tt.player)
+		var gameErr *GameError
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%+v: %v", tt.player, err)
+		case tt.want != "" && (!errors.As(err, &gameErr) || gameErr.Message != tt.want):
+			t.Errorf("%+v: error %v, want %q", tt.player, err, tt.want)
+		}
+	}
+}
+
+func TestWriteErrorIncludesTheRequestID(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/player/nobody", nil)
+	r.Header.Set("X-Request-ID", "req-1")
+	RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, gameError(http.StatusNotFound, "Player not found"))
+	})).ServeHTTP(w, r)
+	if want := `{"message":"Player not found","request_id":"req-1"}` + "\n"; w.Code != http.StatusNotFound || w.Body.String() != want {
+		t.Errorf("status %d, body %s; want 404 with %s", w.Code, w.Body, want)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+	"github.com/google/uuid"
+)
+
+type Action = engine.Action
+
+const (
+	ActionAttack = engine.ActionAttack
+	ActionDefend = engine.ActionDefend
+	ActionHeal   = engine.ActionHeal
+	ActionFlee   = engine.ActionFlee
+	ActionWait   = engine.ActionWait
+)
+
+// A BattleSession is a battle between a player and an enemy. State keeps
+// the combatants as they fight, so item effects last for the battle only.
+type BattleSession struct {
+	ID     string         `json:"id"`
+	Player string         `json:"player"`
+	Enemy  string         `json:"enemy"`
+	Round  int            `json:"round"`
+	Status string         `json:"status"`
+	State  *engine.Battle `json:"state,omitempty"`
+}
+
+const (
+	SessionActive     = engine.Active
+	SessionPlayerWon  = engine.PlayerWon
+	SessionEnemyWon   = engine.EnemyWon
+	SessionPlayerFled = engine.PlayerFled
+	SessionEnemyFled  = engine.EnemyFled
+	SessionDraw       = engine.Draw
+)
+
+var sessions []BattleSession
+
+var lastRounds = map[string]time.Time{}
+var lastRoundsSweep time.Time
+var lastRoundsMu sync.Mutex
+
+// Session returns a copy of the session with the ID.
+func (g *GameService) Session(id string) (BattleSession, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, session := range sessions {
+		if session.ID == id {
+			return session, true
+		}
+	}
+	return BattleSession{}, false
+}
+
+// findOrStartSession returns the index of the active session between the
+// player and the enemy, starting a new one when they are not fighting yet.
+// It expects the lock to be held.
+func findOrStartSession(player, enemy string) int {
+	for i := range sessions {
+		if sessions[i].Player == player && sessions[i].Enemy == enemy && sessions[i].Status == SessionActive {
+			return i
+		}
+	}
+	sessions = append(sessions, BattleSession{
+		ID:     uuid.NewString(),
+		Player: player,
+		Enemy:  enemy,
+		Status: SessionActive,
+	})
+	return len(sessions) - 1
+}
+
+// CooldownLeft returns how long the player still has to wait before the next
+// round.
+func CooldownLeft(player string) time.Duration {
+	lastRoundsMu.Lock()
+	defer lastRoundsMu.Unlock()
+	if wait := lastRounds[player].Add(config.Game.BattleCooldown.Duration).Sub(time.Now()); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// StartCooldown starts the cooldown of the player after a round was played.
+func StartCooldown(player string) {
+	lastRoundsMu.Lock()
+	defer lastRoundsMu.Unlock()
+	now := time.Now()
+	if now.Sub(lastRoundsSweep) > time.Minute {
+		sweepCooldowns(now)
+	}
+	lastRounds[player] = now
+}
+
+// sweepCooldowns drops the cooldowns that are over, as those players are no
+// different from the ones that never fought. It expects lastRoundsMu to be
+// held.
+func sweepCooldowns(now time.Time) {
+	for player, last := range lastRounds {
+		if now.Sub(last) >= config.Game.BattleCooldown.Duration {
+			delete(lastRounds, player)
+		}
+	}
+	lastRoundsSweep = now
+}
+
+// equippedItem is the engine item with the ID, nil when there is none. It
+// expects the lock to be held.
+func equippedItem(id string) *engine.Item {
+	for _, item := range items {
+		if item.ID == id {
+			return &engine.Item{Name: item.Name, EffectType: item.EffectType, EffectValue: item.EffectValue}
+		}
+	}
+	return nil
+}
+
+func (p PlayerRequest) Combatant() engine.Combatant {
+	return engine.Combatant{Name: p.Nickname, Life: p.Life, Attack: p.Attack, Defense: p.Defense, Speed: p.Speed, Item: equippedItem(p.ItemID)}
+}
+
+func (e Enemy) Combatant() engine.Combatant {
+	return engine.Combatant{Name: e.Nickname, Life: e.Life, Attack: e.Attack, Defense: e.Defense, Speed: e.Speed, Behavior: e.Behavior, Item: equippedItem(e.ItemID)}
+}
+
+// keptLife is the life saved for a combatant whose life in the battle went
+// from before to after. The life given by an item absorbs damage first, and
+// healing never restores more than the combatant entered the battle with.
+func keptLife(saved, before, after, base int) int {
+	if after > before {
+		saved = min(saved+after-before, base)
+	}
+	return max(0, min(saved, after))
+}
+
+// ResolveRound plays the next round of the session with the engine and
+// saves the life of the combatants and the state of the session. Only life
+// is saved, the other stats of the combatants are never changed by battles.
+// It expects the lock to be held.
+func ResolveRound(session *BattleSession, player *PlayerRequest, enemy *Enemy, action Action) (Battle, error) {
+	balance := CurrentBalance()
+	if session.State == nil {
+		session.State = engine.NewBattle(balance, player.Combatant(), enemy.Combatant())
+		session.State.Round, session.State.Status = session.Round, session.Status
+	}
+	state := *session.State
+	playerLife, enemyLife := state.Player.Life, state.Enemy.Life
+	round, err := state.Play(balance, action, rng)
+	if err != nil {
+		return Battle{}, err
+	}
+	player.Life = keptLife(player.Life, playerLife, state.Player.Life, state.PlayerBase.Life)
+	enemy.Life = keptLife(enemy.Life, enemyLife, state.Enemy.Life, state.EnemyBase.Life)
+	session.State = &state
+	session.Round, session.Status = state.Round, state.Status
+	return Battle{
+		ID:           uuid.NewString(),
+		SessionID:    session.ID,
+		Round:        round.Number,
+		Enemy:        enemy.Nickname,
+		Player:       player.Nickname,
+		DiceThrown:   round.Dice,
+		Action:       round.Action,
+		EnemyAction:  round.EnemyAction,
+		PlayerDamage: round.PlayerDamage,
+		EnemyDamage:  round.EnemyDamage,
+		Outcome:      round.Outcome,
+		CreatedAt:    time.Now(),
+	}, nil
+}
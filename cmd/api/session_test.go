@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+)
+
+func TestKeptLife(t *testing.T) {
+	for _, tt := range []struct {
+		name                       string
+		saved, before, after, base int
+		want                       int
+	}{
+		{"damage without item", 10, 10, 6, 10, 6},
+		{"item life absorbs damage", 10, 15, 12, 10, 10},
+		{"damage beyond item life", 10, 15, 7, 10, 7},
+		{"killed", 10, 15, 0, 10, 0},
+		{"healed", 4, 4, 7, 10, 7},
+		{"healed no higher than base", 9, 12, 15, 10, 10},
+		{"damaged in another battle", 5, 15, 13, 10, 5},
+	} {
+		if got := keptLife(tt.saved, tt.before, tt.after, tt.base); got != tt.want {
+			t.Errorf("%s: keptLife(%d, %d, %d, %d) = %d, want %d", tt.name, tt.saved, tt.before, tt.after, tt.base, got, tt.want)
+		}
+	}
+}
+
+func TestResolveRoundKeepsItemEffectsInTheBattle(t *testing.T) {
+	currentBalance.Store(engine.DefaultBalance())
+	items = []Item{
+		{ID: "sword", Name: "Sword", EffectType: engine.EffectAttack, EffectValue: 5},
+		{ID: "amulet", Name: "Amulet", EffectType: engine.EffectLife, EffectValue: 5},
+	}
+	defer func() { items = nil }()
+	player := PlayerRequest{Nickname: "hero", Life: 50, Attack: 3, ItemID: "sword"}
+	for i := 0; i < 3; i++ {
+		enemy := Enemy{Nickname: "slime", Life: 4, Attack: 1, ItemID: "amulet"}
+		session := BattleSession{ID: "session", Player: player.Nickname, Enemy: enemy.Nickname, Status: SessionActive}
+		for session.Status == SessionActive {
+			battle, err := ResolveRound(&session, &player, &enemy, ActionAttack)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if battle.PlayerDamage < 8 {
+				t.Fatalf("battle %d round %d: the sword was not equipped, player damage %d", i, battle.Round, battle.PlayerDamage)
+			}
+		}
+		if player.Attack != 3 || enemy.Attack != 1 {
+			t.Fatalf("battle %d: attack saved as %d and %d, want 3 and 1", i, player.Attack, enemy.Attack)
+		}
+		if enemy.Life != 0 || session.State.Enemy.Life != 0 {
+			t.Fatalf("battle %d: enemy life %d, %d in the battle, want 0", i, enemy.Life, session.State.Enemy.Life)
+		}
+	}
+}
+
+func TestCooldowns(t *testing.T) {
+	lastRounds = map[string]time.Time{}
+	defer func() { lastRounds, lastRoundsSweep = map[string]time.Time{}, time.Time{} }()
+	if wait := CooldownLeft("hero"); wait != 0 {
+		t.Errorf("cooldown %v before the first round", wait)
+	}
+	StartCooldown("hero")
+	if wait := CooldownLeft("hero"); wait <= 0 || wait > config.Game.BattleCooldown.Duration {
+		t.Errorf("cooldown %v after a round, want up to %v", wait, config.Game.BattleCooldown)
+	}
+	lastRounds["rested"] = time.Now().Add(-time.Hour)
+	lastRoundsSweep = time.Now().Add(-time.Hour)
+	StartCooldown("other")
+	if _, ok := lastRounds["rested"]; ok || len(lastRounds) != 2 {
+		t.Errorf("cooldowns after a sweep %v, want hero and other", lastRounds)
+	}
+}
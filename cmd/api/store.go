@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// storedAccount keeps the password hash that Account hides from responses.
+type storedAccount struct {
+	Account
+	PasswordHash string `json:"password_hash"`
+	Salt         string `json:"salt"`
+}
+
+type snapshot struct {
+	Players        []PlayerRequest `json:"players"`
+	Enemies        []Enemy         `json:"enemies"`
+	Battles        []Battle        `json:"battles"`
+	Sessions       []BattleSession `json:"sessions"`
+	Items          []Item          `json:"items"`
+	Accounts       []storedAccount `json:"accounts"`
+	EnemyTemplates []EnemyTemplate `json:"enemy_templates"`
+	AuditLog       []AuditEntry    `json:"audit_log"`
+	// Tokens holds the secrets signing the access and refresh tokens, so the
+	// snapshot must stay private like the password hashes.
+	Tokens tokenState `json:"tokens"`
+}
+
+// A Store saves the game data as a JSON snapshot file. A Store without a path
+// keeps the game data in memory only.
+type Store struct {
+	Path string
+
+	mu    sync.Mutex
+	saved []byte // the snapshot last written, to skip unchanged saves
+	err   error  // the error of the last save
+}
+
+// Load reads the snapshot into the game data, returning false when there is
+// no snapshot to read yet.
+func (s *Store) Load() (bool, error) {
+	if s.Path == "" {
+		return false, nil
+	}
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return false, err
+	}
+	game.Update(func() {
+		players, enemies, battles, sessions, items = snap.Players, snap.Enemies, snap.Battles, snap.Sessions, snap.Items
+		enemyTemplates = snap.EnemyTemplates
+		accounts = nil
+		for _, stored := range snap.Accounts {
+			account := stored.Account
+			account.PasswordHash, account.Salt = stored.PasswordHash, stored.Salt
+			accounts = append(accounts, account)
+		}
+		for _, battle := range battles {
+			RecordLeaderboards(battle)
+		}
+	})
+	auditMu.Lock()
+	auditLog = snap.AuditLog
+	auditMu.Unlock()
+	tokens.restore(snap.Tokens)
+	return true, nil
+}
+
+// Save writes the game data to a temporary file renamed over the snapshot, so
+// a crash while saving never leaves a truncated snapshot behind. Nothing is
+// written when the game data has not changed since the last save.
+func (s *Store) Save() error {
+	if s.Path == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var data []byte
+	var err error
+	tokenState := tokens.state()
+	game.View(func() {
+		auditMu.Lock()
+		defer auditMu.Unlock()
+		snap := snapshot{
+			Players:        players,
+			Enemies:        enemies,
+			Battles:        battles,
+			Sessions:       sessions,
+			Items:          items,
+			EnemyTemplates: enemyTemplates,
+			AuditLog:       auditLog,
+			Tokens:         tokenState,
+		}
+		for _, account := range accounts {
+			snap.Accounts = append(snap.Accounts, storedAccount{account, account.PasswordHash, account.Salt})
+		}
+		data, err = json.Marshal(snap)
+	})
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, s.saved) {
+		return s.err
+	}
+	s.err = s.write(data)
+	if s.err == nil {
+		s.saved = data
+	}
+	return s.err
+}
+
+func (s *Store) write(data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(s.Path), ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.Path)
+}
+
+// SaveEvery saves the game data at every interval until ctx is done, so a
+// crash loses at most one interval of changes.
+func (s *Store) SaveEvery(ctx context.Context, interval time.Duration) {
+	if s.Path == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.Save(); err != nil {
+			logger.Error("cannot save game data", "path", s.Path, "error", err)
+		}
+	}
+}
+
+// Available reports whether the snapshot can still be written, by creating a
+// file next to it and by the outcome of the last save.
+func (s *Store) Available() error {
+	if s.Path == "" {
+		return nil
+	}
+	probe, err := os.CreateTemp(filepath.Dir(s.Path), ".probe-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+	if err := os.Remove(probe.Name()); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
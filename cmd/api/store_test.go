@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreSavesChangedData(t *testing.T) {
+	defer func() { players = nil }()
+	store := &Store{Path: filepath.Join(t.TempDir(), "data.json")}
+	players = []PlayerRequest{{Nickname: "Alice"}}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := os.Stat(store.Path)
+
+	// An unchanged snapshot is not written again.
+	os.Remove(store.Path)
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(store.Path); err == nil {
+		t.Error("unchanged data saved again")
+	}
+
+	players = append(players, PlayerRequest{Nickname: "Bob"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	second, err := os.Stat(store.Path)
+	if err != nil || second.Size() <= first.Size() {
+		t.Errorf("changed data not saved: %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(store.Path))
+	if len(entries) != 1 {
+		t.Errorf("%d files left in the data directory, want only the snapshot", len(entries))
+	}
+}
+
+func TestStoreAvailable(t *testing.T) {
+	dir := t.TempDir()
+	if err := (&Store{Path: filepath.Join(dir, "data.json")}).Available(); err != nil {
+		t.Errorf("writable directory: %v", err)
+	}
+	if err := (&Store{Path: filepath.Join(dir, "missing", "data.json")}).Available(); err == nil {
+		t.Error("missing directory reported available")
+	}
+}
+
+func TestStoreKeepsTokensAcrossRestarts(t *testing.T) {
+	defer func(issuer *TokenIssuer) { tokens = issuer }(tokens)
+	tokens = NewTokenIssuer()
+	kept, revoked := tokens.Issue("alice", RefreshToken), tokens.Issue("alice", RefreshToken)
+	claims, err := tokens.Verify(revoked, RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens.Revoke(claims)
+	store := &Store{Path: filepath.Join(t.TempDir(), "data.json")}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens = NewTokenIssuer()
+	if _, err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokens.Verify(kept, RefreshToken); err != nil {
+		t.Errorf("token issued before the restart: %v", err)
+	}
+	if _, err := tokens.Verify(revoked, RefreshToken); err == nil {
+		t.Error("token revoked before the restart accepted")
+	}
+}
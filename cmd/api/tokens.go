@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+// previousKeys is how many rotated out signing keys are still accepted, so
+// tokens issued just before a rotation keep working until they expire.
+const previousKeys = 2
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type Claims struct {
+	Subject   string `json:"sub"`
+	Type      string `json:"typ"`
+	ID        string `json:"jti"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type signingKey struct {
+	ID     string `json:"id"`
+	Secret []byte `json:"secret"`
+}
+
+// tokenState is what a TokenIssuer needs to keep accepting the tokens it
+// issued, and rejecting the ones it revoked, after a restart.
+type tokenState struct {
+	Keys    []signingKey     `json:"keys"`
+	Revoked map[string]int64 `json:"revoked"`
+}
+
+// A TokenIssuer signs HS256 JSON Web Tokens with its current key and verifies
+// them against the current and recently rotated keys.
+type TokenIssuer struct {
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	Now        func() time.Time
+	mu         sync.Mutex
+	keys       []signingKey
+	revoked    map[string]int64
+}
+
+type contextKey string
+
+const accountKey contextKey = "account"
+
+var tokens = NewTokenIssuer()
+
+func NewTokenIssuer() *TokenIssuer {
+	issuer := &TokenIssuer{
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 7 * 24 * time.Hour,
+		Now:        time.Now,
+		revoked:    map[string]int64{},
+	}
+	issuer.RotateKey()
+	return issuer
+}
+
+func (t *TokenIssuer) RotateKey() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := signingKey{ID: randomHex(8), Secret: []byte(randomHex(32))}
+	t.keys = append([]signingKey{key}, t.keys[:min(len(t.keys), previousKeys)]...)
+	return key.ID
+}
+
+// state returns a copy of the signing keys and the revoked tokens.
+func (t *TokenIssuer) state() tokenState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return tokenState{Keys: slices.Clone(t.keys), Revoked: maps.Clone(t.revoked)}
+}
+
+// restore replaces the signing keys and the revoked tokens with a saved
+// state, keeping the current key when the state has none.
+func (t *TokenIssuer) restore(state tokenState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(state.Keys) > 0 {
+		t.keys = slices.Clone(state.Keys)
+	}
+	t.revoked = map[string]int64{}
+	now := t.Now().Unix()
+	for id, expiresAt := range state.Revoked {
+		if expiresAt > now {
+			t.revoked[id] = expiresAt
+		}
+	}
+}
+
+func (t *TokenIssuer) sign(claims Claims) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := t.keys[0]
+	header, _ := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT", "kid": key.ID})
+	payload, _ := json.Marshal(claims)
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, key.Secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (t *TokenIssuer) Issue(username, tokenType string) string {
+	now := t.Now()
+	ttl := t.AccessTTL
+	if tokenType == RefreshToken {
+		ttl = t.RefreshTTL
+	}
+	return t.sign(Claims{
+		Subject:   username,
+		Type:      tokenType,
+		ID:        randomHex(16),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	})
+}
+
+func (t *TokenIssuer) Verify(token, tokenType string) (Claims, error) {
+	var claims Claims
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, ErrInvalidToken
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(headerJSON, &header) != nil || header.Alg != "HS256" {
+		return claims, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, ErrInvalidToken
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	valid := false
+	for _, key := range t.keys {
+		if key.ID == header.Kid {
+			mac := hmac.New(sha256.New, key.Secret)
+			mac.Write([]byte(parts[0] + "." + parts[1]))
+			valid = hmac.Equal(signature, mac.Sum(nil))
+		}
+	}
+	if !valid {
+		return claims, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || json.Unmarshal(payload, &claims) != nil {
+		return claims, ErrInvalidToken
+	}
+	if claims.Type != tokenType || claims.ExpiresAt <= t.Now().Unix() {
+		return claims, ErrInvalidToken
+	}
+	if _, ok := t.revoked[claims.ID]; ok {
+		return claims, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// Revoke rejects the token until it expires, when it is forgotten.
+func (t *TokenIssuer) Revoke(claims Claims) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.Now().Unix()
+	for id, expiresAt := range t.revoked {
+		if expiresAt <= now {
+			delete(t.revoked, id)
+		}
+	}
+	t.revoked[claims.ID] = claims.ExpiresAt
+}
+
+func bearerToken(r *http.Request) string {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token
+}
+
+// authenticate returns the account of a valid access token.
+func authenticate(token string) (*Account, error) {
+	claims, err := tokens.Verify(token, AccessToken)
+	account := FindAccount(claims.Subject)
+	if err != nil || account == nil {
+		return nil, gameError(http.StatusUnauthorized, "Authentication required")
+	}
+	if account.Banned {
+		return nil, gameError(http.StatusForbidden, "Account is banned")
+	}
+	return account, nil
+}
+
+// Authenticated rejects requests without a valid access token and makes the
+// account available to next through CurrentAccount.
+func Authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		account, err := authenticate(bearerToken(r))
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), accountKey, account)))
+	}
+}
+
+// CurrentAccount returns the account authenticated by the Authenticated
+// middleware, or nil for anonymous requests.
+func CurrentAccount(r *http.Request) *Account {
+	return AccountFrom(r.Context())
+}
+
+func AccountFrom(ctx context.Context) *Account {
+	account, _ := ctx.Value(accountKey).(*Account)
+	return account
+}
+
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RotatedKey struct {
+	KeyID string `json:"kid"`
+}
+
+func writeTokens(w http.ResponseWriter, username string) {
+	json.NewEncoder(w).Encode(TokenPair{
+		AccessToken:  tokens.Issue(username, AccessToken),
+		RefreshToken: tokens.Issue(username, RefreshToken),
+		ExpiresIn:    int(tokens.AccessTTL.Seconds()),
+	})
+}
+
+func RefreshTokens(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var request RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Invalid request body"})
+		return
+	}
+	claims, err := tokens.Verify(request.RefreshToken, RefreshToken)
+	account := FindAccount(claims.Subject)
+	if err != nil || account == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Invalid refresh token"})
+		return
+	}
+	if account.Banned {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(PlayerResponse{Message: "Account is banned"})
+		return
+	}
+	tokens.Revoke(claims)
+	writeTokens(w, claims.Subject)
+}
+
+func Logout(w http.ResponseWriter, r *http.Request) {
+	var request RefreshRequest
+	json.NewDecoder(r.Body).Decode(&request)
+	if claims, err := tokens.Verify(bearerToken(r), AccessToken); err == nil {
+		tokens.Revoke(claims)
+	}
+	if claims, err := tokens.Verify(request.RefreshToken, RefreshToken); err == nil {
+		tokens.Revoke(claims)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func RotateSigningKey(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	kid := tokens.RotateKey()
+	RecordAudit(r.Context(), "rotate_signing_key", kid, nil, map[string]string{"kid": kid})
+	json.NewEncoder(w).Encode(RotatedKey{KeyID: kid})
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRefreshTokensRejectsBannedAccounts(t *testing.T) {
+	accounts = []Account{{Username: "alice", Role: RolePlayer}, {Username: "mallory", Role: RolePlayer, Banned: true}}
+	defer func() { accounts = nil }()
+	for _, tt := range []struct {
+		username string
+		want     int
+	}{
+		{"alice", http.StatusOK},
+		{"mallory", http.StatusForbidden},
+		{"nobody", http.StatusUnauthorized},
+	} {
+		body := `{"refresh_token":"` + tokens.Issue(tt.username, RefreshToken) + `"}`
+		w := httptest.NewRecorder()
+		RefreshTokens(w, httptest.NewRequest(http.MethodPost, "/token/refresh", strings.NewReader(body)))
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.username, w.Code, tt.want)
+		}
+	}
+}
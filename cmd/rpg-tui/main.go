@@ -0,0 +1,247 @@
+// Command rpg-tui plays battles round by round in the terminal through the
+// HTTP API.
+//
+//	rpg-tui [-server URL] [-user NAME] [-password PASSWORD]
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/Uemerson/go-simple-rpg-api/client"
+)
+
+func main() {
+	server := flag.String("server", envOr("RPG_SERVER", "http://localhost:8080"), "API base URL")
+	user := flag.String("user", os.Getenv("RPG_USER"), "account username")
+	password := flag.String("password", os.Getenv("RPG_PASSWORD"), "account password")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	g := &game{
+		client: client.New(*server),
+		screen: &screen{in: bufio.NewScanner(os.Stdin), out: os.Stdout},
+	}
+	err := g.run(ctx, *user, *password)
+	fmt.Print(reset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		fmt.Fprintln(os.Stderr, "rpg-tui:", err)
+		os.Exit(1)
+	}
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+type game struct {
+	client *client.Client
+	screen *screen
+	user   string
+}
+
+func (g *game) run(ctx context.Context, user, password string) error {
+	if err := g.login(ctx, user, password); err != nil {
+		return err
+	}
+	for {
+		player, err := g.pickPlayer(ctx)
+		if err != nil {
+			return err
+		}
+		enemy, err := g.pickEnemy(ctx)
+		if err != nil {
+			return err
+		}
+		if err := g.battle(ctx, player, enemy); err != nil {
+			return err
+		}
+		answer, err := g.screen.prompt("\nPlay again? [y/N] ")
+		if err != nil || answer != "y" {
+			return err
+		}
+	}
+}
+
+func (g *game) login(ctx context.Context, user, password string) error {
+	var err error
+	for {
+		g.screen.clear("go-simple-rpg")
+		if err != nil {
+			g.screen.printf("%s%v%s\n\n", red, err, reset)
+		}
+		if user == "" {
+			if user, err = g.screen.prompt("Username: "); err != nil {
+				return err
+			}
+		}
+		if password == "" {
+			if password, err = g.screen.prompt("Password: "); err != nil {
+				return err
+			}
+		}
+		if err = g.client.Login(ctx, user, password); err == nil {
+			g.user = user
+			return nil
+		}
+		user, password = "", ""
+	}
+}
+
+// pickPlayer lets the user choose one of the living characters of their
+// account.
+func (g *game) pickPlayer(ctx context.Context) (client.Player, error) {
+	players, _, err := g.client.ListPlayers(ctx, &client.ListOptions{Sort: "nickname", Filters: map[string]string{"alive": "true"}})
+	if err != nil {
+		return client.Player{}, err
+	}
+	var owned []client.Player
+	var options []string
+	for _, p := range players {
+		if p.Owner == g.user {
+			owned = append(owned, p)
+			options = append(options, fmt.Sprintf("%-16s life %3d  attack %2d  defense %2d  speed %2d", p.Nickname, p.Life, p.Attack, p.Defense, p.Speed))
+		}
+	}
+	if len(owned) == 0 {
+		return client.Player{}, fmt.Errorf("account %s has no living characters, create one first", g.user)
+	}
+	i, err := g.screen.choose("Pick your character", options)
+	if err != nil {
+		return client.Player{}, err
+	}
+	return owned[i], nil
+}
+
+func (g *game) pickEnemy(ctx context.Context) (client.Enemy, error) {
+	enemies, _, err := g.client.ListEnemies(ctx, &client.ListOptions{Sort: "nickname", Filters: map[string]string{"alive": "true"}})
+	if err != nil {
+		return client.Enemy{}, err
+	}
+	if len(enemies) == 0 {
+		return client.Enemy{}, errors.New("there are no living enemies to fight")
+	}
+	options := make([]string, len(enemies))
+	for i, e := range enemies {
+		options[i] = fmt.Sprintf("%-16s life %3d  attack %2d  defense %2d  speed %2d  %s", e.Nickname, e.Life, e.Attack, e.Defense, e.Speed, e.Behavior)
+	}
+	i, err := g.screen.choose("Choose an enemy", options)
+	if err != nil {
+		return client.Enemy{}, err
+	}
+	return enemies[i], nil
+}
+
+var actionKeys = map[string]string{
+	"a": client.ActionAttack,
+	"d": client.ActionDefend,
+	"h": client.ActionHeal,
+	"f": client.ActionFlee,
+	"w": client.ActionWait,
+}
+
+var outcomes = map[string]string{
+	client.OutcomePlayerWon:  green + "Victory!" + reset,
+	client.OutcomeEnemyWon:   red + "Defeat." + reset,
+	client.OutcomePlayerFled: yellow + "You fled the battle." + reset,
+	client.OutcomeEnemyFled:  yellow + "The enemy fled." + reset,
+	client.OutcomeDraw:       yellow + "Draw, the battle lasted too long." + reset,
+}
+
+// battle plays rounds until the battle ends or the user quits. The maximum
+// life of the bars is the life each side had when the battle started, and
+// the current life is reloaded after every round.
+func (g *game) battle(ctx context.Context, player client.Player, enemy client.Enemy) error {
+	playerMax, enemyMax := player.Life, enemy.Life
+	var events []string
+	var last *client.Battle
+	message := ""
+	for {
+		g.draw(player, enemy, playerMax, enemyMax, last, events)
+		if last != nil && last.Outcome != "" {
+			g.screen.printf("\n%s%s%s\n", bold, outcomes[last.Outcome], reset)
+			return nil
+		}
+		if message != "" {
+			g.screen.printf("\n%s%s%s\n", red, message, reset)
+		}
+		key, err := g.screen.prompt("\n[a]ttack  [d]efend  [h]eal  [f]lee  [w]ait  [q]uit: ")
+		if err != nil {
+			return err
+		}
+		if key == "q" {
+			return io.EOF
+		}
+		action, ok := actionKeys[key]
+		if !ok {
+			message = fmt.Sprintf("%q is not an action", key)
+			continue
+		}
+		battle, err := g.client.Battle(ctx, player.Nickname, enemy.Nickname, action)
+		if err != nil {
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) {
+				return err
+			}
+			message = apiErr.Message
+			continue
+		}
+		message = ""
+		last = &battle
+		events = append(events, describe(battle))
+		if player, err = g.client.GetPlayer(ctx, player.Nickname); err != nil {
+			return err
+		}
+		if enemy, err = g.client.GetEnemy(ctx, enemy.Nickname); err != nil {
+			return err
+		}
+		playerMax, enemyMax = max(playerMax, player.Life), max(enemyMax, enemy.Life)
+	}
+}
+
+func (g *game) draw(player client.Player, enemy client.Enemy, playerMax, enemyMax int, last *client.Battle, events []string) {
+	g.screen.clear(fmt.Sprintf("%s vs %s", player.Nickname, enemy.Nickname))
+	g.screen.printf("  %-16s %s\n", player.Nickname, lifeBar(player.Life, playerMax))
+	g.screen.printf("  %-16s %s\n\n", enemy.Nickname, lifeBar(enemy.Life, enemyMax))
+	if last != nil {
+		g.screen.printf("  Round %d   dice %s%s%s\n\n", last.Round, bold, dice(last.DiceThrown), reset)
+	}
+	g.screen.printf("%sEvents%s\n", bold, reset)
+	for _, event := range events[max(0, len(events)-logLines):] {
+		g.screen.printf("  %s\n", event)
+	}
+}
+
+// dice draws the faces of a six sided die, and the number for other rolls.
+func dice(n int) string {
+	faces := []string{"⚀", "⚁", "⚂", "⚃", "⚄", "⚅"}
+	if n >= 1 && n <= len(faces) {
+		return fmt.Sprintf("%s %d", faces[n-1], n)
+	}
+	return fmt.Sprint(n)
+}
+
+func describe(b client.Battle) string {
+	event := fmt.Sprintf("Round %d: %s chose %s and rolled %d", b.Round, b.Player, b.Action, b.DiceThrown)
+	if b.PlayerDamage > 0 {
+		event += fmt.Sprintf(", dealing %d damage", b.PlayerDamage)
+	}
+	event += fmt.Sprintf("; %s chose %s", b.Enemy, b.EnemyAction)
+	if b.EnemyDamage > 0 {
+		event += fmt.Sprintf(", dealing %d damage", b.EnemyDamage)
+	}
+	if b.Outcome != "" {
+		event += " — " + b.Outcome
+	}
+	return event
+}
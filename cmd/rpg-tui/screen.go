@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ANSI escape sequences used to draw the screen.
+const (
+	clear  = "\033[H\033[2J"
+	bold   = "\033[1m"
+	red    = "\033[31m"
+	green  = "\033[32m"
+	yellow = "\033[33m"
+	dim    = "\033[2m"
+	reset  = "\033[0m"
+)
+
+const (
+	barWidth = 30
+	logLines = 10
+)
+
+// screen redraws the whole terminal on every update and reads one line of
+// input at a time, so it needs nothing but an ANSI capable terminal.
+type screen struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+func (s *screen) clear(title string) {
+	fmt.Fprint(s.out, clear+bold+title+reset+"\n\n")
+}
+
+func (s *screen) printf(format string, args ...any) {
+	fmt.Fprintf(s.out, format, args...)
+}
+
+// prompt prints the question and returns the trimmed answer. It returns
+// io.EOF when the input is closed.
+func (s *screen) prompt(question string) (string, error) {
+	fmt.Fprint(s.out, question)
+	if !s.in.Scan() {
+		if err := s.in.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return strings.TrimSpace(s.in.Text()), nil
+}
+
+// choose lists the options numbered from 1 and returns the index of the
+// chosen one.
+func (s *screen) choose(title string, options []string) (int, error) {
+	message := ""
+	for {
+		s.clear(title)
+		for i, option := range options {
+			s.printf("  %s%2d%s  %s\n", yellow, i+1, reset, option)
+		}
+		if message != "" {
+			s.printf("\n%s%s%s\n", red, message, reset)
+		}
+		answer, err := s.prompt("\nChoose a number (q to quit): ")
+		if err != nil {
+			return 0, err
+		}
+		if answer == "q" {
+			return 0, io.EOF
+		}
+		var n int
+		if _, err := fmt.Sscan(answer, &n); err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		message = fmt.Sprintf("%q is not one of the options", answer)
+	}
+}
+
+// lifeBar draws life out of max as a bar, green above half, yellow above a
+// quarter and red below.
+func lifeBar(life, max int) string {
+	if max <= 0 {
+		max = 1
+	}
+	filled := min(barWidth, life*barWidth/max)
+	color := green
+	switch {
+	case life*4 <= max:
+		color = red
+	case life*2 <= max:
+		color = yellow
+	}
+	return color + strings.Repeat("█", filled) + reset + dim + strings.Repeat("░", barWidth-filled) + reset +
+		fmt.Sprintf(" %d/%d", life, max)
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/Uemerson/go-simple-rpg-api/client"
+)
+
+func register(ctx context.Context, c *client.Client, out printer, args []string) error {
+	args, err := parse(flag.NewFlagSet("register", flag.ContinueOnError), args, "<username>", "<password>")
+	if err != nil {
+		return err
+	}
+	account, err := c.Register(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	return out.print(account)
+}
+
+func login(ctx context.Context, c *client.Client, out printer, args []string) error {
+	args, err := parse(flag.NewFlagSet("login", flag.ContinueOnError), args, "<username>", "<password>")
+	if err != nil {
+		return err
+	}
+	if err := c.Login(ctx, args[0], args[1]); err != nil {
+		return err
+	}
+	return out.message("Logged in as %s", args[0])
+}
+
+func logout(ctx context.Context, c *client.Client, out printer, args []string) error {
+	if _, err := parse(flag.NewFlagSet("logout", flag.ContinueOnError), args); err != nil {
+		return err
+	}
+	if err := c.Logout(ctx); err != nil {
+		return err
+	}
+	return out.message("Logged out")
+}
+
+func playerFlags(name string) (*flag.FlagSet, *client.Player) {
+	player := &client.Player{}
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.IntVar(&player.Life, "life", 100, "life")
+	flags.IntVar(&player.Attack, "attack", 10, "attack")
+	flags.IntVar(&player.Defense, "defense", 0, "defense")
+	flags.IntVar(&player.Speed, "speed", 0, "speed")
+	flags.StringVar(&player.ItemID, "item", "", "equipped item ID")
+	return flags, player
+}
+
+func createPlayer(ctx context.Context, c *client.Client, out printer, args []string) error {
+	flags, player := playerFlags("player create")
+	args, err := parse(flags, args, "<nickname>")
+	if err != nil {
+		return err
+	}
+	player.Nickname = args[0]
+	created, err := c.CreatePlayer(ctx, *player)
+	if err != nil {
+		return err
+	}
+	return out.print(created)
+}
+
+// updatePlayer changes only the stats given as flags.
+func updatePlayer(ctx context.Context, c *client.Client, out printer, args []string) error {
+	flags, changes := playerFlags("player update")
+	args, err := parse(flags, args, "<nickname>")
+	if err != nil {
+		return err
+	}
+	player, err := c.GetPlayer(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "life":
+			player.Life = changes.Life
+		case "attack":
+			player.Attack = changes.Attack
+		case "defense":
+			player.Defense = changes.Defense
+		case "speed":
+			player.Speed = changes.Speed
+		case "item":
+			player.ItemID = changes.ItemID
+		}
+	})
+	updated, err := c.UpdatePlayer(ctx, args[0], player)
+	if err != nil {
+		return err
+	}
+	return out.print(updated)
+}
+
+func listPlayers(ctx context.Context, c *client.Client, out printer, args []string) error {
+	flags, options := listFlags("player list")
+	if _, err := parse(flags, args); err != nil {
+		return err
+	}
+	players, total, err := c.ListPlayers(ctx, options)
+	if err != nil {
+		return err
+	}
+	if err := out.print(players); err != nil {
+		return err
+	}
+	out.total(len(players), total)
+	return nil
+}
+
+func getPlayer(ctx context.Context, c *client.Client, out printer, args []string) error {
+	args, err := parse(flag.NewFlagSet("player get", flag.ContinueOnError), args, "<nickname>")
+	if err != nil {
+		return err
+	}
+	player, err := c.GetPlayer(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return out.print(player)
+}
+
+func deletePlayer(ctx context.Context, c *client.Client, out printer, args []string) error {
+	args, err := parse(flag.NewFlagSet("player delete", flag.ContinueOnError), args, "<nickname>")
+	if err != nil {
+		return err
+	}
+	if err := c.DeletePlayer(ctx, args[0]); err != nil {
+		return err
+	}
+	return out.message("Player %s deleted", args[0])
+}
+
+func playerStats(ctx context.Context, c *client.Client, out printer, args []string) error {
+	args, err := parse(flag.NewFlagSet("player stats", flag.ContinueOnError), args, "<nickname>")
+	if err != nil {
+		return err
+	}
+	stats, err := c.PlayerStats(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return out.print(stats)
+}
+
+func spawnEnemy(ctx context.Context, c *client.Client, out printer, args []string) error {
+	enemy := client.Enemy{}
+	flags := flag.NewFlagSet("enemy spawn", flag.ContinueOnError)
+	flags.StringVar(&enemy.Template, "template", "", "enemy template")
+	flags.StringVar(&enemy.Behavior, "behavior", "", "aggressive, defensive, healer, fleeing or random")
+	flags.IntVar(&enemy.Defense, "defense", 0, "defense")
+	flags.StringVar(&enemy.ItemID, "item", "", "equipped item ID")
+	args, err := parse(flags, args, "<nickname>")
+	if err != nil {
+		return err
+	}
+	enemy.Nickname = args[0]
+	created, err := c.CreateEnemy(ctx, enemy)
+	if err != nil {
+		return err
+	}
+	return out.print(created)
+}
+
+func listEnemies(ctx context.Context, c *client.Client, out printer, args []string) error {
+	flags, options := listFlags("enemy list")
+	if _, err := parse(flags, args); err != nil {
+		return err
+	}
+	enemies, total, err := c.ListEnemies(ctx, options)
+	if err != nil {
+		return err
+	}
+	if err := out.print(enemies); err != nil {
+		return err
+	}
+	out.total(len(enemies), total)
+	return nil
+}
+
+func getEnemy(ctx context.Context, c *client.Client, out printer, args []string) error {
+	args, err := parse(flag.NewFlagSet("enemy get", flag.ContinueOnError), args, "<nickname>")
+	if err != nil {
+		return err
+	}
+	enemy, err := c.GetEnemy(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return out.print(enemy)
+}
+
+func deleteEnemy(ctx context.Context, c *client.Client, out printer, args []string) error {
+	args, err := parse(flag.NewFlagSet("enemy delete", flag.ContinueOnError), args, "<nickname>")
+	if err != nil {
+		return err
+	}
+	if err := c.DeleteEnemy(ctx, args[0]); err != nil {
+		return err
+	}
+	return out.message("Enemy %s deleted", args[0])
+}
+
+// startBattle plays the opening round of a battle, or with -auto keeps
+// playing the action until the battle ends.
+func startBattle(ctx context.Context, c *client.Client, out printer, args []string) error {
+	flags := flag.NewFlagSet("battle start", flag.ContinueOnError)
+	action := flags.String("action", client.ActionAttack, "attack, defend, heal, flee or wait")
+	auto := flags.Bool("auto", false, "play rounds until the battle ends")
+	args, err := parse(flags, args, "<player>", "<enemy>")
+	if err != nil {
+		return err
+	}
+	var rounds []client.Battle
+	for {
+		battle, err := c.Battle(ctx, args[0], args[1], *action)
+		if err != nil {
+			return err
+		}
+		rounds = append(rounds, battle)
+		if !*auto || battle.Outcome != "" {
+			break
+		}
+	}
+	return out.print(rounds)
+}
+
+func playTurn(ctx context.Context, c *client.Client, out printer, args []string) error {
+	flags := flag.NewFlagSet("battle turn", flag.ContinueOnError)
+	action := flags.String("action", client.ActionAttack, "attack, defend, heal, flee or wait")
+	args, err := parse(flags, args, "<player>", "<enemy>")
+	if err != nil {
+		return err
+	}
+	battle, err := c.Battle(ctx, args[0], args[1], *action)
+	if err != nil {
+		return err
+	}
+	return out.print(battle)
+}
+
+func listBattles(ctx context.Context, c *client.Client, out printer, args []string) error {
+	flags, options := listFlags("battle list")
+	if _, err := parse(flags, args); err != nil {
+		return err
+	}
+	battles, total, err := c.ListBattles(ctx, options)
+	if err != nil {
+		return err
+	}
+	if err := out.print(battles); err != nil {
+		return err
+	}
+	out.total(len(battles), total)
+	return nil
+}
+
+func createItem(ctx context.Context, c *client.Client, out printer, args []string) error {
+	item := client.Item{}
+	flags := flag.NewFlagSet("item create", flag.ContinueOnError)
+	flags.StringVar(&item.EffectType, "effect", "attack", "attack, defense or life")
+	flags.IntVar(&item.EffectValue, "value", 1, "effect value")
+	args, err := parse(flags, args, "<name>")
+	if err != nil {
+		return err
+	}
+	item.Name = args[0]
+	created, err := c.CreateItem(ctx, item)
+	if err != nil {
+		return err
+	}
+	return out.print(created)
+}
+
+func listItems(ctx context.Context, c *client.Client, out printer, args []string) error {
+	flags, options := listFlags("item list")
+	if _, err := parse(flags, args); err != nil {
+		return err
+	}
+	items, total, err := c.ListItems(ctx, options)
+	if err != nil {
+		return err
+	}
+	if err := out.print(items); err != nil {
+		return err
+	}
+	out.total(len(items), total)
+	return nil
+}
+
+func leaderboard(ctx context.Context, c *client.Client, out printer, args []string) error {
+	flags := flag.NewFlagSet("leaderboard", flag.ContinueOnError)
+	board := flags.String("board", "wins", "wins, xp, enemies_defeated or highest_hit")
+	window := flags.String("window", "all", "all or weekly")
+	limit := flags.Int("limit", 10, "number of entries")
+	if _, err := parse(flags, args); err != nil {
+		return err
+	}
+	leaderboard, err := c.Leaderboard(ctx, *board, *window, *limit)
+	if err != nil {
+		return err
+	}
+	return out.print(leaderboard)
+}
@@ -0,0 +1,239 @@
+// Command rpgctl plays and administers the game through the HTTP API.
+//
+//	rpgctl [-server URL] [-o table|json] <command> <subcommand> [flags] [args]
+//
+// Login stores the tokens in the user's config directory, and the other
+// commands send them along.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Uemerson/go-simple-rpg-api/client"
+)
+
+const usage = `usage: rpgctl [-server URL] [-o table|json] <command> [flags] [args]
+
+commands:
+  register <username> <password>
+  login <username> <password>
+  logout
+  player create [-life N] [-attack N] [-defense N] [-speed N] [-item ID] <nickname>
+  player list [-limit N] [-offset N] [-sort FIELDS] [-filter name=value]
+  player get <nickname>
+  player update [-life N] [-attack N] [-defense N] [-speed N] [-item ID] <nickname>
+  player delete <nickname>
+  player stats <nickname>
+  enemy spawn [-template NAME] [-behavior NAME] [-defense N] [-item ID] <nickname>
+  enemy list [-limit N] [-offset N] [-sort FIELDS] [-filter name=value]
+  enemy get <nickname>
+  enemy delete <nickname>
+  battle start [-action ACTION] [-auto] <player> <enemy>
+  battle turn [-action ACTION] <player> <enemy>
+  battle list [-limit N] [-offset N] [-sort FIELDS] [-filter name=value]
+  item create [-effect TYPE] [-value N] <name>
+  item list [-limit N] [-offset N] [-sort FIELDS] [-filter name=value]
+  leaderboard [-board NAME] [-window all|weekly] [-limit N]
+`
+
+type command func(ctx context.Context, c *client.Client, out printer, args []string) error
+
+var commands = map[string]command{
+	"register":      register,
+	"login":         login,
+	"logout":        logout,
+	"player create": createPlayer,
+	"player list":   listPlayers,
+	"player get":    getPlayer,
+	"player update": updatePlayer,
+	"player delete": deletePlayer,
+	"player stats":  playerStats,
+	"enemy spawn":   spawnEnemy,
+	"enemy list":    listEnemies,
+	"enemy get":     getEnemy,
+	"enemy delete":  deleteEnemy,
+	"battle start":  startBattle,
+	"battle turn":   playTurn,
+	"battle list":   listBattles,
+	"item create":   createItem,
+	"item list":     listItems,
+	"leaderboard":   leaderboard,
+}
+
+func main() {
+	flags := flag.NewFlagSet("rpgctl", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage) }
+	server := flags.String("server", envOr("RPG_SERVER", "http://localhost:8080"), "API base URL")
+	format := flags.String("o", "table", "output format, table or json")
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(2)
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "rpgctl: invalid output format %q, must be table or json\n", *format)
+		os.Exit(2)
+	}
+
+	args := flags.Args()
+	run, args := lookup(args)
+	if run == nil {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	c := client.New(*server)
+	session, err := loadSession()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "rpgctl:", err)
+		os.Exit(1)
+	}
+	if session.Server == c.BaseURL {
+		c.AccessToken, c.RefreshToken = session.AccessToken, session.RefreshToken
+	}
+
+	err = run(ctx, c, newPrinter(*format, os.Stdout), args)
+	if c.RefreshToken != session.RefreshToken {
+		if err := saveSession(c); err != nil {
+			fmt.Fprintln(os.Stderr, "rpgctl:", err)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "rpgctl:", err)
+		os.Exit(1)
+	}
+}
+
+// lookup finds the command named by the first one or two arguments and
+// returns it with the remaining arguments.
+func lookup(args []string) (command, []string) {
+	if len(args) >= 2 {
+		if run, ok := commands[args[0]+" "+args[1]]; ok {
+			return run, args[2:]
+		}
+	}
+	if len(args) >= 1 {
+		if run, ok := commands[args[0]]; ok {
+			return run, args[1:]
+		}
+	}
+	return nil, nil
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// session is the login state kept between invocations.
+type session struct {
+	Server       string `json:"server"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func sessionPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "rpgctl", "session.json"), nil
+}
+
+func loadSession() (session, error) {
+	var s session
+	path, err := sessionPath()
+	if err != nil {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("read session %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func saveSession(c *client.Client) error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	if c.RefreshToken == "" {
+		err := os.Remove(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(session{Server: c.BaseURL, AccessToken: c.AccessToken, RefreshToken: c.RefreshToken})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// filters collects repeated -filter name=value flags.
+type filters map[string]string
+
+func (f filters) String() string {
+	pairs := make([]string, 0, len(f))
+	for name, value := range f {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (f filters) Set(value string) error {
+	name, v, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("invalid filter %q, must be name=value", value)
+	}
+	f[name] = v
+	return nil
+}
+
+func listFlags(name string) (*flag.FlagSet, *client.ListOptions) {
+	options := &client.ListOptions{Filters: filters{}}
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.IntVar(&options.Limit, "limit", 0, "maximum number of results")
+	flags.IntVar(&options.Offset, "offset", 0, "number of results to skip")
+	flags.StringVar(&options.Sort, "sort", "", "comma separated fields, prefixed with - for descending order")
+	flags.Var(filters(options.Filters), "filter", "name=value filter, may be repeated")
+	return flags, options
+}
+
+// parse parses the flags of a command and checks that exactly want
+// positional arguments remain.
+func parse(flags *flag.FlagSet, args []string, want ...string) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() != len(want) {
+		return nil, fmt.Errorf("%s expects %d argument(s): %s", flags.Name(), len(want), strings.Join(want, " "))
+	}
+	return flags.Args(), nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Uemerson/go-simple-rpg-api/client"
+)
+
+// A printer writes command results.
+type printer interface {
+	// print writes a result, an API value or a slice of them.
+	print(v any) error
+	message(format string, args ...any) error
+	// total notes that a list shows only part of the matching records.
+	total(shown, total int)
+}
+
+func newPrinter(format string, w io.Writer) printer {
+	if format == "json" {
+		return jsonPrinter{w}
+	}
+	return tablePrinter{w}
+}
+
+// jsonPrinter writes results as indented JSON, for scripts.
+type jsonPrinter struct {
+	w io.Writer
+}
+
+func (p jsonPrinter) print(v any) error {
+	encoder := json.NewEncoder(p.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func (p jsonPrinter) message(format string, args ...any) error {
+	return p.print(map[string]string{"message": fmt.Sprintf(format, args...)})
+}
+
+func (p jsonPrinter) total(shown, total int) {}
+
+// tablePrinter writes results as tab aligned tables with a row per record.
+type tablePrinter struct {
+	w io.Writer
+}
+
+func (p tablePrinter) print(v any) error {
+	switch v := v.(type) {
+	case client.Account:
+		return p.table([]string{"USERNAME", "ROLE"}, [][]any{{v.Username, v.Role}})
+	case client.Player:
+		return p.print([]client.Player{v})
+	case []client.Player:
+		rows := make([][]any, len(v))
+		for i, player := range v {
+			rows[i] = []any{player.Nickname, player.Life, player.Attack, player.Defense, player.Speed, player.ItemID, player.Owner}
+		}
+		return p.table([]string{"NICKNAME", "LIFE", "ATTACK", "DEFENSE", "SPEED", "ITEM", "OWNER"}, rows)
+	case client.Enemy:
+		return p.print([]client.Enemy{v})
+	case []client.Enemy:
+		rows := make([][]any, len(v))
+		for i, enemy := range v {
+			rows[i] = []any{enemy.Nickname, enemy.Life, enemy.Attack, enemy.Defense, enemy.Speed, enemy.Behavior, enemy.ItemID}
+		}
+		return p.table([]string{"NICKNAME", "LIFE", "ATTACK", "DEFENSE", "SPEED", "BEHAVIOR", "ITEM"}, rows)
+	case client.Item:
+		return p.print([]client.Item{v})
+	case []client.Item:
+		rows := make([][]any, len(v))
+		for i, item := range v {
+			rows[i] = []any{item.ID, item.Name, item.EffectType, item.EffectValue}
+		}
+		return p.table([]string{"ID", "NAME", "EFFECT", "VALUE"}, rows)
+	case client.Battle:
+		return p.print([]client.Battle{v})
+	case []client.Battle:
+		rows := make([][]any, len(v))
+		for i, b := range v {
+			outcome := b.Outcome
+			if outcome == "" {
+				outcome = "-"
+			}
+			rows[i] = []any{b.Round, b.Player, b.Action, b.Enemy, b.EnemyAction, b.DiceThrown, b.PlayerDamage, b.EnemyDamage, outcome}
+		}
+		return p.table([]string{"ROUND", "PLAYER", "ACTION", "ENEMY", "ENEMY ACTION", "DICE", "DAMAGE DEALT", "DAMAGE TAKEN", "OUTCOME"}, rows)
+	case client.BattleStats:
+		return p.table([]string{"BATTLES", "WINS", "LOSSES", "WIN RATE", "DAMAGE DEALT", "DAMAGE TAKEN", "AVERAGE DICE", "LONGEST BATTLE"},
+			[][]any{{v.Battles, v.Wins, v.Losses, v.WinRate, v.DamageDealt, v.DamageTaken, v.AverageDice, v.LongestBattle}})
+	case client.Leaderboard:
+		rows := make([][]any, len(v.Entries))
+		for i, s := range v.Entries {
+			rows[i] = []any{i + 1, s.Player, s.Wins, s.XP, s.Level, s.EnemiesDefeated, s.HighestHit}
+		}
+		return p.table([]string{"RANK", "PLAYER", "WINS", "XP", "LEVEL", "ENEMIES DEFEATED", "HIGHEST HIT"}, rows)
+	}
+	return fmt.Errorf("cannot print %T as a table", v)
+}
+
+func (p tablePrinter) table(header []string, rows [][]any) error {
+	table := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, strings.Join(header, "\t"))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = fmt.Sprint(cell)
+		}
+		fmt.Fprintln(table, strings.Join(cells, "\t"))
+	}
+	return table.Flush()
+}
+
+func (p tablePrinter) message(format string, args ...any) error {
+	_, err := fmt.Fprintf(p.w, format+"\n", args...)
+	return err
+}
+
+func (p tablePrinter) total(shown, total int) {
+	if total > shown {
+		fmt.Fprintf(p.w, "(%d of %d)\n", shown, total)
+	}
+}
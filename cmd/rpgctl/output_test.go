@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Uemerson/go-simple-rpg-api/client"
+)
+
+func TestPrinters(t *testing.T) {
+	player := client.Player{Nickname: "Hero", Life: 50, Attack: 5, ItemID: "1", Owner: "alice"}
+	for _, tt := range []struct {
+		format string
+		v      any
+		want   string
+	}{
+		{"table", player, "NICKNAME  LIFE  ATTACK  DEFENSE  SPEED  ITEM  OWNER\nHero      50    5       0        0      1     alice\n"},
+		{"table", []client.Item{{ID: "1", Name: "Sword", EffectType: "attack", EffectValue: 5}, {ID: "2", Name: "Shield", EffectType: "defense", EffectValue: 3}},
+			"ID  NAME    EFFECT   VALUE\n1   Sword   attack   5\n2   Shield  defense  3\n"},
+		{"table", client.Battle{Round: 1, Player: "Hero", Action: "attack", Enemy: "Slime", EnemyAction: "attack"},
+			"ROUND  PLAYER  ACTION  ENEMY  ENEMY ACTION  DICE  DAMAGE DEALT  DAMAGE TAKEN  OUTCOME\n1      Hero    attack  Slime  attack        0     0             0             -\n"},
+		{"json", player, "{\n  \"nickname\": \"Hero\",\n  \"life\": 50,\n  \"attack\": 5,\n  \"defense\": 0,\n  \"speed\": 0,\n  \"item_id\": \"1\",\n  \"owner\": \"alice\"\n}\n"},
+		{"json", []client.Player{}, "[]\n"},
+	} {
+		var out strings.Builder
+		if err := newPrinter(tt.format, &out).print(tt.v); err != nil {
+			t.Errorf("%s %T: %v", tt.format, tt.v, err)
+		} else if out.String() != tt.want {
+			t.Errorf("%s %T:\n%q\nwant\n%q", tt.format, tt.v, out.String(), tt.want)
+		}
+	}
+	if err := newPrinter("table", &strings.Builder{}).print(struct{}{}); err == nil {
+		t.Error("unknown value printed as a table")
+	}
+}
@@ -0,0 +1,300 @@
+// Command simulate plays seeded battles through the game engine to compare
+// stat profiles and balance rulesets.
+//
+//	simulate -config config.json -player life=50,attack=10 -enemy behavior=healer -balance hard.json
+//
+// Enemies without life, attack or speed roll them from the balance like
+// spawned enemies. Profiles written as @nickname are the stored players and
+// enemies of the -data file. Every ruleset plays the same seeded battles.
+// The first one is the baseline: the balance of the API server -config file,
+// or the -baseline balance file, over the default balance. The -balance
+// rulesets are read over the baseline and compared with it.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+)
+
+// list is a flag that can be given several times.
+type list []string
+
+func (l *list) String() string {
+	return strings.Join(*l, " ")
+}
+
+func (l *list) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type ruleset struct {
+	Name    string
+	Balance *engine.Balance
+}
+
+// readBalance reads a balance file over the defaults, like the API server
+// does, so the file only needs the values it changes.
+func readBalance(path string, defaults *engine.Balance) (*engine.Balance, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	b := *defaults
+	b.ItemEffects = maps.Clone(defaults.ItemEffects)
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := b.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &b, nil
+}
+
+func rulesetName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+func loadRuleset(path string, baseline ruleset) (ruleset, error) {
+	b, err := readBalance(path, baseline.Balance)
+	if err != nil {
+		return ruleset{}, err
+	}
+	return ruleset{Name: rulesetName(path), Balance: b}, nil
+}
+
+// serverConfig is the part of an API server config file the balance is
+// built from.
+type serverConfig struct {
+	Game struct {
+		EnemyLife   *engine.Range `json:"enemy_life"`
+		EnemyAttack *engine.Range `json:"enemy_attack"`
+		EnemySpeed  *engine.Range `json:"enemy_speed"`
+		DiceSides   int           `json:"dice_sides"`
+		BalancePath string        `json:"balance_path"`
+	} `json:"game"`
+}
+
+// loadBaseline builds the balance the API server starts with from its config
+// file: the default balance with the enemy stats and dice of the game
+// section, then its balance file, resolved from the directory of the config
+// file. The baseline balance file, when given, is read over it.
+func loadBaseline(configPath, balancePath string) (ruleset, error) {
+	rules := ruleset{Name: "default", Balance: engine.DefaultBalance()}
+	if configPath != "" {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return rules, err
+		}
+		var config serverConfig
+		if err := json.Unmarshal(data, &config); err != nil {
+			return rules, fmt.Errorf("%s: %w", configPath, err)
+		}
+		game, b := config.Game, rules.Balance
+		for _, r := range []struct {
+			value *engine.Range
+			stat  *engine.Range
+		}{
+			{game.EnemyLife, &b.EnemyLife},
+			{game.EnemyAttack, &b.EnemyAttack},
+			{game.EnemySpeed, &b.EnemySpeed},
+		} {
+			if r.value != nil {
+				*r.stat = *r.value
+			}
+		}
+		if game.DiceSides != 0 {
+			b.DiceSides = game.DiceSides
+		}
+		if err := b.Validate(); err != nil {
+			return rules, fmt.Errorf("%s: %w", configPath, err)
+		}
+		rules.Name = rulesetName(configPath)
+		if path := game.BalancePath; path != "" {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(filepath.Dir(configPath), path)
+			}
+			if rules.Balance, err = readBalance(path, b); err != nil {
+				return rules, err
+			}
+		}
+	}
+	if balancePath != "" {
+		b, err := readBalance(balancePath, rules.Balance)
+		if err != nil {
+			return rules, err
+		}
+		rules = ruleset{Name: rulesetName(balancePath), Balance: b}
+	}
+	return rules, nil
+}
+
+// strategy chooses the actions of the player.
+type strategy func(rng *rand.Rand) engine.Action
+
+func parseStrategy(name string) (strategy, error) {
+	if name == "random" {
+		actions := []engine.Action{engine.ActionAttack, engine.ActionDefend, engine.ActionHeal, engine.ActionWait}
+		return func(rng *rand.Rand) engine.Action { return actions[rng.Intn(len(actions))] }, nil
+	}
+	action := engine.Action(name)
+	if !engine.PlayerActions[action] {
+		return nil, fmt.Errorf("action must be attack, defend, heal, flee, wait or random, got %q", name)
+	}
+	return func(*rand.Rand) engine.Action { return action }, nil
+}
+
+type options struct {
+	battles   int
+	maxRounds int
+	seed      int64
+	play      strategy
+}
+
+// simulate plays the battles of a matchup under a ruleset.
+func simulate(rules ruleset, player, enemy profile, opts options) (Result, error) {
+	rng := rand.New(rand.NewSource(opts.seed))
+	result := Result{Ruleset: rules.Name, Player: player.Name, Enemy: enemy.Name, Battles: opts.battles, Outcomes: map[string]int{}}
+	var rounds, dealt, taken []int
+	for i := 0; i < opts.battles; i++ {
+		battle := engine.NewBattle(rules.Balance, player.combatant(rules.Balance, false, rng), enemy.combatant(rules.Balance, true, rng))
+		damageDealt, damageTaken := 0, 0
+		for battle.Status == engine.Active && battle.Round < opts.maxRounds {
+			round, err := battle.Play(rules.Balance, opts.play(rng), rng)
+			if err != nil {
+				return result, fmt.Errorf("%s against %s: %w", player.Name, enemy.Name, err)
+			}
+			damageDealt += round.PlayerDamage
+			damageTaken += round.EnemyDamage
+		}
+		outcome := battle.Status
+		if outcome == engine.Active {
+			outcome = Unfinished
+		}
+		result.Outcomes[outcome]++
+		rounds = append(rounds, battle.Round)
+		dealt = append(dealt, damageDealt)
+		taken = append(taken, damageTaken)
+	}
+	result.WinRate = result.rate(engine.PlayerWon)
+	result.Rounds = distributionOf(rounds)
+	result.DamageDealt = distributionOf(dealt)
+	result.DamageTaken = distributionOf(taken)
+	return result, nil
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	var playerFlags, enemyFlags, balanceFlags list
+	flags.Var(&playerFlags, "player", "player profile, as life=50,attack=3-5,defense=2,speed=1,item=attack:4 or @nickname; repeatable")
+	flags.Var(&enemyFlags, "enemy", "enemy profile, as the player profiles with a behavior; repeatable, a spawned enemy by default")
+	flags.Var(&balanceFlags, "balance", "balance file of a ruleset to compare with the baseline, read over it; repeatable")
+	configPath := flags.String("config", "", "API server config file whose balance is the baseline")
+	baselinePath := flags.String("baseline", "", "balance file of the baseline, read over the -config balance")
+	dataPath := flags.String("data", "", "API data file the @nickname profiles are read from")
+	battles := flags.Int("battles", 1000, "battles played per matchup and ruleset")
+	maxRounds := flags.Int("max-rounds", 100, "rounds after which a battle counts as unfinished")
+	seed := flags.Int64("seed", 1, "random seed, the same seed plays the same battles")
+	action := flags.String("action", "attack", "player action every round: attack, defend, heal, flee, wait or random")
+	format := flags.String("o", "text", "output format: text, csv or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", flags.Args())
+	}
+
+	var errs []error
+	if len(playerFlags) == 0 {
+		errs = append(errs, errors.New("at least one -player profile is required"))
+	}
+	if *battles < 1 || *maxRounds < 1 {
+		errs = append(errs, errors.New("battles and max rounds must be at least 1"))
+	}
+	write := map[string]func(io.Writer, []Result) error{
+		"text": writeText,
+		"csv":  writeCSV,
+		"json": writeJSON,
+	}[*format]
+	if write == nil {
+		errs = append(errs, fmt.Errorf("output format must be text, csv or json, got %q", *format))
+	}
+	play, err := parseStrategy(*action)
+	errs = append(errs, err)
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	var snap *snapshot
+	if *dataPath != "" {
+		if snap, err = loadSnapshot(*dataPath); err != nil {
+			return err
+		}
+	}
+	playerProfiles, err := loadProfiles(playerFlags, snap, false)
+	if err != nil {
+		return err
+	}
+	enemyProfiles, err := loadProfiles(enemyFlags, snap, true)
+	if err != nil {
+		return err
+	}
+	if len(enemyProfiles) == 0 {
+		enemyProfiles = []profile{{Name: "spawned", Stats: map[string]engine.Range{}}}
+	}
+	baseline, err := loadBaseline(*configPath, *baselinePath)
+	if err != nil {
+		return err
+	}
+	rulesets := []ruleset{baseline}
+	for _, path := range balanceFlags {
+		rules, err := loadRuleset(path, baseline)
+		if err != nil {
+			return err
+		}
+		rulesets = append(rulesets, rules)
+	}
+
+	opts := options{battles: *battles, maxRounds: *maxRounds, seed: *seed, play: play}
+	var results []Result
+	for _, player := range playerProfiles {
+		for _, enemy := range enemyProfiles {
+			var baseline float64
+			for i, rules := range rulesets {
+				result, err := simulate(rules, player, enemy, opts)
+				if err != nil {
+					return err
+				}
+				if i == 0 {
+					baseline = result.WinRate
+				}
+				result.WinRateDelta = result.WinRate - baseline
+				results = append(results, result)
+			}
+		}
+	}
+	return write(os.Stdout, results)
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, "simulate:", err)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+)
+
+func TestLoadBaseline(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	config := write("server.json", `{"server": {"addr": ":8080"}, "game": {"enemy_life": {"min": 20, "max": 30}, "dice_sides": 8, "balance_path": "live.json"}}`)
+	write("live.json", `{"heal_value": 5, "dice_sides": 10}`)
+	baseline := write("next.json", `{"low_life": 1}`)
+
+	rules, err := loadBaseline("", "")
+	if err != nil || rules.Name != "default" || rules.Balance.DiceSides != engine.DefaultBalance().DiceSides {
+		t.Errorf("without files: %s %+v, %v", rules.Name, rules.Balance, err)
+	}
+
+	rules, err = loadBaseline(config, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := rules.Balance
+	if rules.Name != "server" || b.EnemyLife != (engine.Range{Min: 20, Max: 30}) || b.EnemyAttack != engine.DefaultBalance().EnemyAttack || b.DiceSides != 10 || b.HealValue != 5 {
+		t.Errorf("from the config: %s %+v", rules.Name, b)
+	}
+
+	rules, err = loadBaseline(config, baseline)
+	if err != nil || rules.Name != "next" || rules.Balance.LowLife != 1 || rules.Balance.HealValue != 5 {
+		t.Errorf("with a baseline file: %s %+v, %v", rules.Name, rules.Balance, err)
+	}
+
+	if _, err := loadBaseline(write("bad.json", `{"game": {"dice_sides": 1}}`), ""); err == nil {
+		t.Error("config with a 1 sided dice accepted")
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Uemerson/go-simple-rpg-api/client"
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+)
+
+// A profile describes one side of the simulated battles. Its stats are
+// ranges rolled again for every battle, and the life, attack and speed of
+// enemies left unset are rolled from the balance like spawned enemies.
+type profile struct {
+	Name     string
+	Stats    map[string]engine.Range
+	Behavior string
+	Item     *engine.Item
+}
+
+var statNames = []string{"life", "attack", "defense", "speed"}
+
+// parseProfile reads a profile such as
+// "name=tank,life=50,attack=3-5,defense=2,item=attack:4,behavior=healer".
+func parseProfile(text string, enemy bool) (profile, error) {
+	p := profile{Name: text, Stats: map[string]engine.Range{}}
+	for _, field := range strings.Split(text, ",") {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return p, fmt.Errorf("profile %q: expected key=value, got %q", text, field)
+		}
+		switch key {
+		case "name":
+			p.Name = value
+		case "life", "attack", "defense", "speed":
+			r, err := parseRange(value)
+			if err != nil {
+				return p, fmt.Errorf("profile %q: %s: %w", text, key, err)
+			}
+			p.Stats[key] = r
+		case "behavior":
+			if !enemy {
+				return p, fmt.Errorf("profile %q: only enemies have a behavior", text)
+			}
+			if _, ok := engine.Behaviors[value]; !ok {
+				return p, fmt.Errorf("profile %q: behavior must be aggressive, defensive, healer, fleeing or random", text)
+			}
+			p.Behavior = value
+		case "item":
+			effectType, effectValue, _ := strings.Cut(value, ":")
+			n, err := strconv.Atoi(effectValue)
+			if !engine.ValidEffect(effectType) || err != nil {
+				return p, fmt.Errorf("profile %q: item must be attack, defense or life and a value, as attack:5", text)
+			}
+			p.Item = &engine.Item{Name: value, EffectType: effectType, EffectValue: n}
+		default:
+			return p, fmt.Errorf("profile %q: unknown key %q", text, key)
+		}
+	}
+	if !enemy {
+		if _, ok := p.Stats["life"]; !ok {
+			return p, fmt.Errorf("profile %q: players need a life", text)
+		}
+	}
+	if r, ok := p.Stats["life"]; ok && r.Min < 1 {
+		return p, fmt.Errorf("profile %q: life must be at least 1", text)
+	}
+	return p, nil
+}
+
+// parseRange reads a stat as "5" or "1-10".
+func parseRange(value string) (engine.Range, error) {
+	var r engine.Range
+	if !strings.Contains(value, "-") {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return r, fmt.Errorf("expected a number or a range such as 1-10, got %q", value)
+		}
+		return engine.Range{Min: n, Max: n}, nil
+	}
+	if err := r.Set(value); err != nil {
+		return r, err
+	}
+	if r.Min < 0 || r.Max < r.Min {
+		return r, fmt.Errorf("range %q must not be negative or end before it starts", value)
+	}
+	return r, nil
+}
+
+// combatant rolls the stats of a combatant of the profile.
+func (p profile) combatant(b *engine.Balance, enemy bool, rng *rand.Rand) engine.Combatant {
+	c := engine.Combatant{Name: p.Name, Behavior: p.Behavior, Item: p.Item}
+	if enemy {
+		c = b.SpawnEnemy(b.EnemyLife, b.EnemyAttack, rng)
+		c.Name, c.Behavior, c.Item = p.Name, p.Behavior, p.Item
+	}
+	for _, stat := range []struct {
+		name  string
+		value *int
+	}{
+		{"life", &c.Life},
+		{"attack", &c.Attack},
+		{"defense", &c.Defense},
+		{"speed", &c.Speed},
+	} {
+		if r, ok := p.Stats[stat.name]; ok {
+			*stat.value = r.Roll(rng)
+		}
+	}
+	return c
+}
+
+// snapshot is the part of the API data file the stored profiles come from.
+type snapshot struct {
+	Players []client.Player `json:"players"`
+	Enemies []client.Enemy  `json:"enemies"`
+	Items   []client.Item   `json:"items"`
+}
+
+func loadSnapshot(path string) (*snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+func (s *snapshot) item(id string) *engine.Item {
+	for _, item := range s.Items {
+		if item.ID == id {
+			return &engine.Item{Name: item.Name, EffectType: item.EffectType, EffectValue: item.EffectValue}
+		}
+	}
+	return nil
+}
+
+func fixed(values ...int) map[string]engine.Range {
+	stats := map[string]engine.Range{}
+	for i, value := range values {
+		stats[statNames[i]] = engine.Range{Min: value, Max: value}
+	}
+	return stats
+}
+
+// storedProfile is the profile of a stored player or enemy with its current
+// stats and item.
+func (s *snapshot) storedProfile(nickname string, enemy bool) (profile, error) {
+	if s == nil {
+		return profile{}, fmt.Errorf("stored profile @%s needs a data file", nickname)
+	}
+	var p *profile
+	if enemy {
+		for _, e := range s.Enemies {
+			if e.Nickname == nickname {
+				p = &profile{Name: "@" + nickname, Stats: fixed(e.Life, e.Attack, e.Defense, e.Speed), Behavior: e.Behavior, Item: s.item(e.ItemID)}
+			}
+		}
+	} else {
+		for _, player := range s.Players {
+			if player.Nickname == nickname {
+				p = &profile{Name: "@" + nickname, Stats: fixed(player.Life, player.Attack, player.Defense, player.Speed), Item: s.item(player.ItemID)}
+			}
+		}
+	}
+	if p == nil {
+		return profile{}, fmt.Errorf("@%s is not a stored player or enemy", nickname)
+	}
+	if p.Stats["life"].Min < 1 {
+		return profile{}, fmt.Errorf("@%s is dead and cannot battle", nickname)
+	}
+	return *p, nil
+}
+
+// loadProfiles parses the profiles of one side, "@nickname" naming a stored
+// player or enemy.
+func loadProfiles(texts []string, snap *snapshot, enemy bool) ([]profile, error) {
+	var profiles []profile
+	for _, text := range texts {
+		var p profile
+		var err error
+		if nickname, ok := strings.CutPrefix(text, "@"); ok {
+			p, err = snap.storedProfile(nickname, enemy)
+		} else {
+			p, err = parseProfile(text, enemy)
+		}
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Uemerson/go-simple-rpg-api/engine"
+)
+
+// Unfinished counts the battles still going after the maximum rounds.
+const Unfinished = "unfinished"
+
+var outcomes = []string{engine.PlayerWon, engine.EnemyWon, engine.PlayerFled, engine.EnemyFled, engine.Draw, Unfinished}
+
+type Distribution struct {
+	Mean float64 `json:"mean"`
+	Min  int     `json:"min"`
+	P50  int     `json:"p50"`
+	P90  int     `json:"p90"`
+	Max  int     `json:"max"`
+}
+
+func distributionOf(values []int) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	total := 0
+	for _, value := range sorted {
+		total += value
+	}
+	percentile := func(p int) int {
+		return sorted[(len(sorted)-1)*p/100]
+	}
+	return Distribution{
+		Mean: float64(total) / float64(len(sorted)),
+		Min:  sorted[0],
+		P50:  percentile(50),
+		P90:  percentile(90),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// A Result sums up the battles of a player profile against an enemy profile
+// under a ruleset. Damage is totalled per battle.
+type Result struct {
+	Ruleset  string         `json:"ruleset"`
+	Player   string         `json:"player"`
+	Enemy    string         `json:"enemy"`
+	Battles  int            `json:"battles"`
+	Outcomes map[string]int `json:"outcomes"`
+	WinRate  float64        `json:"win_rate"`
+	// WinRateDelta is the difference with the win rate of the same matchup
+	// under the first ruleset.
+	WinRateDelta float64      `json:"win_rate_delta"`
+	Rounds       Distribution `json:"rounds"`
+	DamageDealt  Distribution `json:"damage_dealt"`
+	DamageTaken  Distribution `json:"damage_taken"`
+}
+
+func (r Result) rate(outcome string) float64 {
+	return float64(r.Outcomes[outcome]) / float64(r.Battles)
+}
+
+func percent(rate float64) string {
+	return strconv.FormatFloat(rate*100, 'f', 1, 64) + "%"
+}
+
+func (d Distribution) String() string {
+	return fmt.Sprintf("%.1f [%d %d %d %d]", d.Mean, d.Min, d.P50, d.P90, d.Max)
+}
+
+func writeText(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "RULESET\tPLAYER\tENEMY\tBATTLES\tWIN\tΔ WIN\tLOSS\tFLED\tENEMY FLED\tDRAW\tUNFINISHED\tROUNDS\tDAMAGE DEALT\tDAMAGE TAKEN")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%+.1f\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Ruleset, r.Player, r.Enemy, r.Battles,
+			percent(r.WinRate), r.WinRateDelta*100, percent(r.rate(engine.EnemyWon)), percent(r.rate(engine.PlayerFled)),
+			percent(r.rate(engine.EnemyFled)), percent(r.rate(engine.Draw)), percent(r.rate(Unfinished)),
+			r.Rounds, r.DamageDealt, r.DamageTaken)
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Distributions are the mean [min median p90 max] per battle.")
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	header := []string{"ruleset", "player", "enemy", "battles"}
+	header = append(header, outcomes...)
+	header = append(header, "win_rate", "win_rate_delta")
+	for _, name := range []string{"rounds", "damage_dealt", "damage_taken"} {
+		header = append(header, name+"_mean", name+"_min", name+"_p50", name+"_p90", name+"_max")
+	}
+	cw.Write(header)
+	for _, r := range results {
+		record := []string{r.Ruleset, r.Player, r.Enemy, strconv.Itoa(r.Battles)}
+		for _, outcome := range outcomes {
+			record = append(record, strconv.Itoa(r.Outcomes[outcome]))
+		}
+		record = append(record, strconv.FormatFloat(r.WinRate, 'f', 4, 64), strconv.FormatFloat(r.WinRateDelta, 'f', 4, 64))
+		for _, d := range []Distribution{r.Rounds, r.DamageDealt, r.DamageTaken} {
+			record = append(record, strconv.FormatFloat(d.Mean, 'f', 2, 64), strconv.Itoa(d.Min), strconv.Itoa(d.P50), strconv.Itoa(d.P90), strconv.Itoa(d.Max))
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, results []Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
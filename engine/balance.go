@@ -0,0 +1,151 @@
+// Package engine implements the rules of the game: combatants, item
+// effects, enemy behaviors and the rounds of a battle. It keeps no state and
+// does no I/O, so the API server, simulations and bots all play by the same
+// rules.
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Range is an inclusive range of stat values, written as "1-10" in flags and
+// environment variables.
+type Range struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+func (r *Range) String() string {
+	return strconv.Itoa(r.Min) + "-" + strconv.Itoa(r.Max)
+}
+
+func (r *Range) Set(value string) error {
+	low, high, ok := strings.Cut(value, "-")
+	lowValue, err := strconv.Atoi(low)
+	highValue, err2 := strconv.Atoi(high)
+	if !ok || err != nil || err2 != nil {
+		return fmt.Errorf("expected a range such as 1-10, got %q", value)
+	}
+	r.Min, r.Max = lowValue, highValue
+	return nil
+}
+
+// Bounds describes the range as "1 and 10" for error messages.
+func (r Range) Bounds() string {
+	return strconv.Itoa(r.Min) + " and " + strconv.Itoa(r.Max)
+}
+
+func (r Range) Contains(value int) bool {
+	return value >= r.Min && value <= r.Max
+}
+
+func (r Range) Roll(rng *rand.Rand) int {
+	return rng.Intn(r.Max-r.Min+1) + r.Min
+}
+
+type FleeBalance struct {
+	Base     int `json:"base"`
+	PerSpeed int `json:"per_speed"`
+	Min      int `json:"min"`
+	Max      int `json:"max"`
+}
+
+// Balance holds the values the rules are tuned with. A Balance is never
+// modified once in use, changing the rules means playing with a new one.
+type Balance struct {
+	EnemyLife        Range       `json:"enemy_life"`
+	EnemyAttack      Range       `json:"enemy_attack"`
+	EnemySpeed       Range       `json:"enemy_speed"`
+	DiceSides        int         `json:"dice_sides"`
+	DefendMultiplier int         `json:"defend_multiplier"`
+	HealValue        int         `json:"heal_value"`
+	LowLife          int         `json:"low_life"`
+	Flee             FleeBalance `json:"flee"`
+	// MaxRounds is the number of rounds after which a battle ends in a draw.
+	MaxRounds   int            `json:"max_rounds"`
+	ItemEffects map[string]int `json:"item_effects"`
+}
+
+// DefaultBalance is the balance the game is played with unless configured
+// otherwise.
+func DefaultBalance() *Balance {
+	return &Balance{
+		EnemyLife:        Range{Min: 1, Max: 10},
+		EnemyAttack:      Range{Min: 1, Max: 10},
+		EnemySpeed:       Range{Min: 1, Max: 10},
+		DiceSides:        6,
+		DefendMultiplier: 2,
+		HealValue:        3,
+		LowLife:          3,
+		Flee:             FleeBalance{Base: 50, PerSpeed: 10, Min: 10, Max: 90},
+		MaxRounds:        100,
+		ItemEffects:      map[string]int{},
+	}
+}
+
+// ItemEffect is the effect value of the item, unless the balance overrides
+// it by item name.
+func (b *Balance) ItemEffect(item Item) int {
+	if value, ok := b.ItemEffects[item.Name]; ok {
+		return value
+	}
+	return item.EffectValue
+}
+
+// FleeChance is the percentage chance of a combatant escaping its opponent,
+// the base chance between combatants of the same speed, moved for each point
+// of difference.
+func (b *Balance) FleeChance(speed, opponentSpeed int) int {
+	return min(b.Flee.Max, max(b.Flee.Min, b.Flee.Base+(speed-opponentSpeed)*b.Flee.PerSpeed))
+}
+
+// SpawnEnemy rolls the stats of a new enemy within the given life and attack
+// ranges and the balance speed range.
+func (b *Balance) SpawnEnemy(life, attack Range, rng *rand.Rand) Combatant {
+	return Combatant{
+		Life:   life.Roll(rng),
+		Attack: attack.Roll(rng),
+		Speed:  b.EnemySpeed.Roll(rng),
+	}
+}
+
+func (b *Balance) Validate() error {
+	var errs []error
+	for _, r := range []struct {
+		name  string
+		value Range
+	}{
+		{"enemy life", b.EnemyLife},
+		{"enemy attack", b.EnemyAttack},
+		{"enemy speed", b.EnemySpeed},
+	} {
+		if r.value.Min < 1 || r.value.Max < r.value.Min {
+			errs = append(errs, fmt.Errorf("%s range must start at 1 or more and not end before it starts, got %d-%d", r.name, r.value.Min, r.value.Max))
+		}
+	}
+	if b.DiceSides < 2 {
+		errs = append(errs, fmt.Errorf("dice must have at least 2 sides, got %d", b.DiceSides))
+	}
+	if b.DefendMultiplier < 1 {
+		errs = append(errs, fmt.Errorf("defend multiplier must be at least 1, got %d", b.DefendMultiplier))
+	}
+	if b.HealValue < 0 || b.LowLife < 0 {
+		errs = append(errs, errors.New("heal value and low life cannot be negative"))
+	}
+	if b.Flee.Min < 0 || b.Flee.Max > 100 || b.Flee.Min > b.Flee.Max {
+		errs = append(errs, fmt.Errorf("flee chance must stay within 0 and 100, got %d to %d", b.Flee.Min, b.Flee.Max))
+	}
+	if b.MaxRounds < 1 {
+		errs = append(errs, fmt.Errorf("max rounds must be at least 1, got %d", b.MaxRounds))
+	}
+	for name, value := range b.ItemEffects {
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("effect value of item %q cannot be negative", name))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFleeChance(t *testing.T) {
+	b := DefaultBalance()
+	for _, tt := range []struct {
+		playerSpeed, enemySpeed int
+		want                    int
+	}{
+		{5, 5, 50},
+		{7, 5, 70},
+		{3, 5, 30},
+		{10, 1, 90},
+		{1, 10, 10},
+	} {
+		if got := b.FleeChance(tt.playerSpeed, tt.enemySpeed); got != tt.want {
+			t.Errorf("FleeChance(%d, %d) = %d, want %d", tt.playerSpeed, tt.enemySpeed, got, tt.want)
+		}
+	}
+}
+
+func TestRangeSet(t *testing.T) {
+	for _, tt := range []struct {
+		value string
+		want  Range
+		err   bool
+	}{
+		{value: "1-10", want: Range{Min: 1, Max: 10}},
+		{value: "5-5", want: Range{Min: 5, Max: 5}},
+		{value: "10", err: true},
+		{value: "a-b", err: true},
+	} {
+		var r Range
+		err := r.Set(tt.value)
+		if (err != nil) != tt.err || (!tt.err && r != tt.want) {
+			t.Errorf("Set(%q) = %v, %v; want %v, error %t", tt.value, r, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestSpawnEnemyStaysInRange(t *testing.T) {
+	b := DefaultBalance()
+	rng := rand.New(rand.NewSource(seed))
+	life, attack := Range{Min: 3, Max: 6}, Range{Min: 1, Max: 2}
+	for i := 0; i < 100; i++ {
+		enemy := b.SpawnEnemy(life, attack, rng)
+		if !life.Contains(enemy.Life) || !attack.Contains(enemy.Attack) || !b.EnemySpeed.Contains(enemy.Speed) {
+			t.Fatalf("spawned %+v outside of the ranges", enemy)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := DefaultBalance().Validate(); err != nil {
+		t.Errorf("default balance: %v", err)
+	}
+	for name, change := range map[string]func(*Balance){
+		"empty range":     func(b *Balance) { b.EnemyLife = Range{Min: 5, Max: 4} },
+		"one-sided dice":  func(b *Balance) { b.DiceSides = 1 },
+		"no defend bonus": func(b *Balance) { b.DefendMultiplier = 0 },
+		"negative heal":   func(b *Balance) { b.HealValue = -1 },
+		"flee above 100":  func(b *Balance) { b.Flee.Max = 101 },
+		"negative effect": func(b *Balance) { b.ItemEffects["Sword"] = -1 },
+	} {
+		b := DefaultBalance()
+		change(b)
+		if b.Validate() == nil {
+			t.Errorf("%s: validated", name)
+		}
+	}
+}
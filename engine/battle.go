@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// Status of a battle, the outcome of the round that ended it.
+const (
+	Active     = "active"
+	PlayerWon  = "player_won"
+	EnemyWon   = "enemy_won"
+	PlayerFled = "player_fled"
+	EnemyFled  = "enemy_fled"
+	// Draw ends a battle still going after the maximum rounds of the
+	// balance.
+	Draw = "draw"
+)
+
+var (
+	ErrInvalidAction = errors.New("invalid action, must be attack, defend, heal, flee or wait")
+	ErrBattleOver    = errors.New("battle is over")
+	ErrCombatantDead = errors.New("one of the combatants is dead, battle cannot proceed")
+)
+
+// A Battle is a fight between a player and an enemy, played one round at a
+// time until one of them dies or flees, or the rounds run out. Player and Enemy are the combatants
+// as they fight, with their items equipped, and PlayerBase and EnemyBase the
+// combatants as they entered the battle.
+type Battle struct {
+	Player     Combatant `json:"player"`
+	Enemy      Combatant `json:"enemy"`
+	PlayerBase Combatant `json:"player_base"`
+	EnemyBase  Combatant `json:"enemy_base"`
+	Round      int       `json:"round"`
+	Status     string    `json:"status"`
+}
+
+// A Round is the result of playing a round of a battle.
+type Round struct {
+	Number       int
+	Dice         int
+	Action       Action
+	EnemyAction  Action
+	PlayerDamage int
+	EnemyDamage  int
+	// Outcome is the status the battle ended with, empty while it goes on.
+	Outcome string
+}
+
+// NewBattle starts a battle between the combatants, who equip their items
+// for the length of the battle.
+func NewBattle(b *Balance, player, enemy Combatant) *Battle {
+	battle := &Battle{Player: player, Enemy: enemy, PlayerBase: player, EnemyBase: enemy, Status: Active}
+	b.Equip(&battle.Player)
+	b.Equip(&battle.Enemy)
+	battle.Player.MaxLife, battle.Enemy.MaxLife = battle.Player.Life, battle.Enemy.Life
+	return battle
+}
+
+// Play resolves a round with the player action.
+func (battle *Battle) Play(b *Balance, action Action, rng *rand.Rand) (Round, error) {
+	if !PlayerActions[action] {
+		return Round{}, ErrInvalidAction
+	}
+	if battle.Status != Active {
+		return Round{}, ErrBattleOver
+	}
+	player, enemy := &battle.Player, &battle.Enemy
+	if !player.Alive() || !enemy.Alive() {
+		return Round{}, ErrCombatantDead
+	}
+	battle.Round++
+	round := Round{
+		Number:      battle.Round,
+		Action:      action,
+		EnemyAction: b.ChooseEnemyAction(enemy, player, rng),
+	}
+	round.Dice = rng.Intn(b.DiceSides) + 1
+	if action == ActionFlee && rng.Intn(100) < b.FleeChance(player.Speed, enemy.Speed) {
+		return battle.end(round, PlayerFled), nil
+	}
+	// An enemy that fails to flee loses its turn.
+	if round.EnemyAction == ActionFlee && rng.Intn(100) < b.FleeChance(enemy.Speed, player.Speed) {
+		return battle.end(round, EnemyFled), nil
+	}
+	if action == ActionHeal {
+		player.Heal(b.HealValue)
+	}
+	if round.EnemyAction == ActionHeal {
+		enemy.Heal(b.HealValue)
+	}
+	if action == ActionAttack {
+		enemyDefense := enemy.Defense
+		if round.EnemyAction == ActionDefend {
+			enemyDefense *= b.DefendMultiplier
+		}
+		round.PlayerDamage = max(0, player.Attack-enemyDefense+round.Dice)
+		enemy.Life = max(0, enemy.Life-round.PlayerDamage)
+	}
+	if round.EnemyAction == ActionAttack {
+		playerDefense := player.Defense
+		if action == ActionDefend {
+			playerDefense *= b.DefendMultiplier
+		}
+		round.EnemyDamage = max(0, enemy.Attack-playerDefense)
+		player.Life = max(0, player.Life-round.EnemyDamage)
+	}
+	if !enemy.Alive() {
+		return battle.end(round, PlayerWon), nil
+	}
+	if !player.Alive() {
+		return battle.end(round, EnemyWon), nil
+	}
+	if battle.Round >= b.MaxRounds {
+		return battle.end(round, Draw), nil
+	}
+	return round, nil
+}
+
+func (battle *Battle) end(round Round, status string) Round {
+	battle.Status = status
+	round.Outcome = status
+	return round
+}
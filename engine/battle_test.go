@@ -0,0 +1,227 @@
+package engine
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+const seed = 42
+
+// firstDice is the dice Play rolls first with a generator seeded with seed.
+func firstDice(b *Balance) int {
+	return rand.New(rand.NewSource(seed)).Intn(b.DiceSides) + 1
+}
+
+func TestNewBattleEquipsItems(t *testing.T) {
+	b := DefaultBalance()
+	player := Combatant{Name: "hero", Life: 10, Attack: 3, Item: &Item{Name: "Sword", EffectType: EffectAttack, EffectValue: 2}}
+	enemy := Combatant{Name: "slime", Life: 5, Attack: 1, Item: &Item{Name: "Amulet", EffectType: EffectLife, EffectValue: 4}}
+	battle := NewBattle(b, player, enemy)
+	if battle.Player.Attack != 5 || battle.Player.MaxLife != 10 {
+		t.Errorf("player attack %d, max life %d, want 5 and 10", battle.Player.Attack, battle.Player.MaxLife)
+	}
+	if battle.Enemy.Life != 9 || battle.Enemy.MaxLife != 9 {
+		t.Errorf("enemy life %d, max life %d, want 9 and 9", battle.Enemy.Life, battle.Enemy.MaxLife)
+	}
+	if battle.PlayerBase.Attack != 3 || battle.EnemyBase.Life != 5 {
+		t.Errorf("base attack %d and life %d, want 3 and 5", battle.PlayerBase.Attack, battle.EnemyBase.Life)
+	}
+	if battle.Status != Active || battle.Round != 0 {
+		t.Errorf("status %q round %d, want active round 0", battle.Status, battle.Round)
+	}
+}
+
+func TestPlay(t *testing.T) {
+	b := DefaultBalance()
+	certainFlee := DefaultBalance()
+	certainFlee.Flee = FleeBalance{Base: 100, Min: 100, Max: 100}
+	noFlee := DefaultBalance()
+	noFlee.Flee = FleeBalance{}
+	dice := firstDice(b)
+
+	for _, tt := range []struct {
+		name          string
+		balance       *Balance
+		player, enemy Combatant
+		action        Action
+		want          Round
+		playerLife    int
+		enemyLife     int
+		status        string
+	}{
+		{
+			name:       "attack an aggressive enemy",
+			balance:    b,
+			player:     Combatant{Life: 20, Attack: 5, Defense: 1},
+			enemy:      Combatant{Life: 20, Attack: 4, Defense: 2, Behavior: "aggressive"},
+			action:     ActionAttack,
+			want:       Round{Number: 1, Dice: dice, Action: ActionAttack, EnemyAction: ActionAttack, PlayerDamage: 3 + dice, EnemyDamage: 3},
+			playerLife: 17, enemyLife: 17 - dice, status: Active,
+		},
+		{
+			name:       "defend doubles the player defense",
+			balance:    b,
+			player:     Combatant{Life: 20, Attack: 5, Defense: 2},
+			enemy:      Combatant{Life: 20, Attack: 5, Behavior: "aggressive"},
+			action:     ActionDefend,
+			want:       Round{Number: 1, Dice: dice, Action: ActionDefend, EnemyAction: ActionAttack, EnemyDamage: 1},
+			playerLife: 19, enemyLife: 20, status: Active,
+		},
+		{
+			name:       "a defending enemy doubles its defense",
+			balance:    b,
+			player:     Combatant{Life: 20, Attack: 3},
+			enemy:      Combatant{Life: 7, Attack: 5, Defense: 2, Behavior: "defensive"},
+			action:     ActionAttack,
+			want:       Round{Number: 1, Dice: dice, Action: ActionAttack, EnemyAction: ActionDefend, PlayerDamage: max(0, dice-1)},
+			playerLife: 20, enemyLife: 7 - max(0, dice-1), status: Active,
+		},
+		{
+			name:       "the player wins",
+			balance:    b,
+			player:     Combatant{Life: 20, Attack: 10},
+			enemy:      Combatant{Life: 3, Attack: 5, Behavior: "aggressive"},
+			action:     ActionAttack,
+			want:       Round{Number: 1, Dice: dice, Action: ActionAttack, EnemyAction: ActionAttack, PlayerDamage: 10 + dice, EnemyDamage: 5, Outcome: PlayerWon},
+			playerLife: 15, enemyLife: 0, status: PlayerWon,
+		},
+		{
+			name:       "the enemy wins",
+			balance:    b,
+			player:     Combatant{Life: 2, Attack: 1},
+			enemy:      Combatant{Life: 50, Attack: 5, Behavior: "aggressive"},
+			action:     ActionWait,
+			want:       Round{Number: 1, Dice: dice, Action: ActionWait, EnemyAction: ActionAttack, EnemyDamage: 5, Outcome: EnemyWon},
+			playerLife: 0, enemyLife: 50, status: EnemyWon,
+		},
+		{
+			name:       "the player flees",
+			balance:    certainFlee,
+			player:     Combatant{Life: 20, Attack: 1},
+			enemy:      Combatant{Life: 20, Attack: 5, Behavior: "aggressive"},
+			action:     ActionFlee,
+			want:       Round{Number: 1, Dice: dice, Action: ActionFlee, EnemyAction: ActionAttack, Outcome: PlayerFled},
+			playerLife: 20, enemyLife: 20, status: PlayerFled,
+		},
+		{
+			name:       "the player fails to flee",
+			balance:    noFlee,
+			player:     Combatant{Life: 20, Attack: 1},
+			enemy:      Combatant{Life: 20, Attack: 5, Behavior: "aggressive"},
+			action:     ActionFlee,
+			want:       Round{Number: 1, Dice: dice, Action: ActionFlee, EnemyAction: ActionAttack, EnemyDamage: 5},
+			playerLife: 15, enemyLife: 20, status: Active,
+		},
+		{
+			name:       "the enemy flees",
+			balance:    certainFlee,
+			player:     Combatant{Life: 20, Attack: 1},
+			enemy:      Combatant{Life: 2, Attack: 5, Behavior: "fleeing"},
+			action:     ActionAttack,
+			want:       Round{Number: 1, Dice: dice, Action: ActionAttack, EnemyAction: ActionFlee, Outcome: EnemyFled},
+			playerLife: 20, enemyLife: 2, status: EnemyFled,
+		},
+		{
+			name:       "the enemy fails to flee",
+			balance:    noFlee,
+			player:     Combatant{Life: 20, Attack: 1},
+			enemy:      Combatant{Life: 2, Attack: 5, Behavior: "fleeing"},
+			action:     ActionWait,
+			want:       Round{Number: 1, Dice: dice, Action: ActionWait, EnemyAction: ActionFlee},
+			playerLife: 20, enemyLife: 2, status: Active,
+		},
+		{
+			name:       "healing stops at the starting life",
+			balance:    b,
+			player:     Combatant{Life: 20, MaxLife: 21, Attack: 1},
+			enemy:      Combatant{Life: 2, MaxLife: 4, Attack: 5, Behavior: "healer"},
+			action:     ActionHeal,
+			want:       Round{Number: 1, Dice: dice, Action: ActionHeal, EnemyAction: ActionHeal},
+			playerLife: 21, enemyLife: 4, status: Active,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			battle := &Battle{Player: tt.player, Enemy: tt.enemy, Status: Active}
+			round, err := battle.Play(tt.balance, tt.action, rand.New(rand.NewSource(seed)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if round != tt.want {
+				t.Errorf("round %+v, want %+v", round, tt.want)
+			}
+			if battle.Player.Life != tt.playerLife || battle.Enemy.Life != tt.enemyLife {
+				t.Errorf("life %d and %d, want %d and %d", battle.Player.Life, battle.Enemy.Life, tt.playerLife, tt.enemyLife)
+			}
+			if battle.Status != tt.status || battle.Round != 1 {
+				t.Errorf("status %q round %d, want %q round 1", battle.Status, battle.Round, tt.status)
+			}
+		})
+	}
+}
+
+func TestPlayErrors(t *testing.T) {
+	alive := Combatant{Life: 10, Attack: 1}
+	for _, tt := range []struct {
+		name   string
+		battle Battle
+		action Action
+		want   error
+	}{
+		{"unknown action", Battle{Player: alive, Enemy: alive, Status: Active}, "dance", ErrInvalidAction},
+		{"finished battle", Battle{Player: alive, Enemy: alive, Status: PlayerWon}, ActionAttack, ErrBattleOver},
+		{"dead combatant", Battle{Player: alive, Enemy: Combatant{}, Status: Active}, ActionAttack, ErrCombatantDead},
+	} {
+		battle := tt.battle
+		if _, err := battle.Play(DefaultBalance(), tt.action, rand.New(rand.NewSource(seed))); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.want)
+		}
+		if battle.Round != tt.battle.Round {
+			t.Errorf("%s: round %d played", tt.name, battle.Round)
+		}
+	}
+}
+
+func TestPlayIsReplayedBySeed(t *testing.T) {
+	play := func() []Round {
+		b := DefaultBalance()
+		battle := NewBattle(b, Combatant{Life: 30, Attack: 3, Speed: 2}, Combatant{Life: 30, Attack: 3, Behavior: "random"})
+		rng := rand.New(rand.NewSource(seed))
+		var rounds []Round
+		for battle.Status == Active {
+			round, err := battle.Play(b, ActionAttack, rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rounds = append(rounds, round)
+		}
+		return rounds
+	}
+	first, second := play(), play()
+	if len(first) != len(second) {
+		t.Fatalf("%d rounds, then %d with the same seed", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("round %d: %+v, then %+v with the same seed", i+1, first[i], second[i])
+		}
+	}
+}
+
+func TestPlayEndsInADrawAfterTheMaxRounds(t *testing.T) {
+	b := DefaultBalance()
+	b.MaxRounds = 3
+	// Neither side can hurt the other.
+	battle := NewBattle(b, Combatant{Life: 10, Defense: 20}, Combatant{Life: 10, Defense: 20, Behavior: "defensive"})
+	rng := rand.New(rand.NewSource(seed))
+	var round Round
+	for battle.Status == Active {
+		var err error
+		if round, err = battle.Play(b, ActionWait, rng); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if battle.Status != Draw || round.Outcome != Draw || battle.Round != 3 {
+		t.Errorf("status %q, outcome %q after %d rounds, want a draw after 3", battle.Status, round.Outcome, battle.Round)
+	}
+}
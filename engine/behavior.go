@@ -0,0 +1,76 @@
+package engine
+
+import "math/rand"
+
+type Action string
+
+const (
+	ActionAttack Action = "attack"
+	ActionDefend Action = "defend"
+	ActionHeal   Action = "heal"
+	ActionFlee   Action = "flee"
+	ActionWait   Action = "wait"
+)
+
+// PlayerActions are the actions players can choose.
+var PlayerActions = map[Action]bool{
+	ActionAttack: true,
+	ActionDefend: true,
+	ActionHeal:   true,
+	ActionFlee:   true,
+	ActionWait:   true,
+}
+
+// A Behavior chooses the action of an enemy for the next round.
+type Behavior func(enemy, player *Combatant, b *Balance, rng *rand.Rand) Action
+
+var Behaviors = map[string]Behavior{
+	"aggressive": Aggressive,
+	"defensive":  Defensive,
+	"healer":     Healer,
+	"fleeing":    Fleeing,
+	"random":     Random,
+}
+
+// ChooseEnemyAction asks the behavior of the enemy for its action, enemies
+// without a known behavior being aggressive.
+func (b *Balance) ChooseEnemyAction(enemy, player *Combatant, rng *rand.Rand) Action {
+	behavior, ok := Behaviors[enemy.Behavior]
+	if !ok {
+		behavior = Aggressive
+	}
+	return behavior(enemy, player, b, rng)
+}
+
+func Aggressive(enemy, player *Combatant, b *Balance, rng *rand.Rand) Action {
+	return ActionAttack
+}
+
+func Defensive(enemy, player *Combatant, b *Balance, rng *rand.Rand) Action {
+	// Defend whenever the player's highest dice roll would be enough to kill.
+	if enemy.Life <= player.Attack-enemy.Defense+b.DiceSides {
+		return ActionDefend
+	}
+	return ActionAttack
+}
+
+// Healer heals once its life gets as low as the balance low life.
+func Healer(enemy, player *Combatant, b *Balance, rng *rand.Rand) Action {
+	if enemy.Life <= b.LowLife {
+		return ActionHeal
+	}
+	return ActionAttack
+}
+
+// Fleeing runs away once its life gets as low as the balance low life.
+func Fleeing(enemy, player *Combatant, b *Balance, rng *rand.Rand) Action {
+	if enemy.Life <= b.LowLife {
+		return ActionFlee
+	}
+	return ActionAttack
+}
+
+func Random(enemy, player *Combatant, b *Balance, rng *rand.Rand) Action {
+	actions := []Action{ActionAttack, ActionDefend, ActionHeal, ActionWait}
+	return actions[rng.Intn(len(actions))]
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBehaviors(t *testing.T) {
+	b := DefaultBalance()
+	player := &Combatant{Life: 10, Attack: 4}
+	for _, tt := range []struct {
+		behavior string
+		enemy    Combatant
+		want     Action
+	}{
+		{"aggressive", Combatant{Life: 1}, ActionAttack},
+		{"defensive", Combatant{Life: 20, Defense: 1}, ActionAttack},
+		// The highest roll deals 4 - 1 + 6 = 9 damage.
+		{"defensive", Combatant{Life: 9, Defense: 1}, ActionDefend},
+		{"healer", Combatant{Life: b.LowLife + 1}, ActionAttack},
+		{"healer", Combatant{Life: b.LowLife}, ActionHeal},
+		{"fleeing", Combatant{Life: b.LowLife + 1}, ActionAttack},
+		{"fleeing", Combatant{Life: b.LowLife}, ActionFlee},
+		{"unknown", Combatant{Life: 1}, ActionAttack},
+		{"", Combatant{Life: 1}, ActionAttack},
+	} {
+		enemy := tt.enemy
+		enemy.Behavior = tt.behavior
+		if got := b.ChooseEnemyAction(&enemy, player, rand.New(rand.NewSource(seed))); got != tt.want {
+			t.Errorf("%q enemy with life %d: %s, want %s", tt.behavior, enemy.Life, got, tt.want)
+		}
+	}
+}
+
+func TestRandomBehavior(t *testing.T) {
+	b := DefaultBalance()
+	enemy, player := &Combatant{Life: 10}, &Combatant{Life: 10}
+	first, second := rand.New(rand.NewSource(seed)), rand.New(rand.NewSource(seed))
+	seen := map[Action]bool{}
+	for i := 0; i < 100; i++ {
+		action := Random(enemy, player, b, first)
+		if again := Random(enemy, player, b, second); again != action {
+			t.Fatalf("draw %d: %s, then %s with the same seed", i, action, again)
+		}
+		seen[action] = true
+	}
+	for _, action := range []Action{ActionAttack, ActionDefend, ActionHeal, ActionWait} {
+		if !seen[action] {
+			t.Errorf("%s never chosen in 100 draws", action)
+		}
+	}
+	if seen[ActionFlee] {
+		t.Errorf("random enemies flee")
+	}
+}
@@ -0,0 +1,57 @@
+package engine
+
+const (
+	EffectAttack  = "attack"
+	EffectDefense = "defense"
+	EffectLife    = "life"
+)
+
+// ValidEffect reports whether items can have the effect type.
+func ValidEffect(effectType string) bool {
+	return effectType == EffectAttack || effectType == EffectDefense || effectType == EffectLife
+}
+
+type Item struct {
+	Name        string `json:"name"`
+	EffectType  string `json:"effect_type"`
+	EffectValue int    `json:"effect_value"`
+}
+
+// A Combatant is a player or an enemy in a battle. Behavior picks the
+// actions of enemies and is ignored for players, who choose their own.
+// MaxLife is the life the combatant started the battle with, the most
+// healing restores.
+type Combatant struct {
+	Name     string `json:"name"`
+	Life     int    `json:"life"`
+	MaxLife  int    `json:"max_life"`
+	Attack   int    `json:"attack"`
+	Defense  int    `json:"defense"`
+	Speed    int    `json:"speed"`
+	Behavior string `json:"behavior,omitempty"`
+	Item     *Item  `json:"item,omitempty"`
+}
+
+func (c *Combatant) Alive() bool {
+	return c.Life > 0
+}
+
+// Heal restores life, up to the life the combatant started the battle with.
+func (c *Combatant) Heal(value int) {
+	c.Life = max(c.Life, min(c.Life+value, c.MaxLife))
+}
+
+// Equip applies the effect of the item of the combatant to its stats.
+func (b *Balance) Equip(c *Combatant) {
+	if c.Item == nil {
+		return
+	}
+	switch c.Item.EffectType {
+	case EffectAttack:
+		c.Attack += b.ItemEffect(*c.Item)
+	case EffectDefense:
+		c.Defense += b.ItemEffect(*c.Item)
+	case EffectLife:
+		c.Life += b.ItemEffect(*c.Item)
+	}
+}
@@ -0,0 +1,47 @@
+package engine
+
+import "testing"
+
+func TestEquip(t *testing.T) {
+	b := DefaultBalance()
+	b.ItemEffects["Cursed Sword"] = 1
+	base := Combatant{Life: 10, Attack: 3, Defense: 2}
+	for _, tt := range []struct {
+		name string
+		item *Item
+		want Combatant
+	}{
+		{"no item", nil, Combatant{Life: 10, Attack: 3, Defense: 2}},
+		{"attack", &Item{Name: "Sword", EffectType: EffectAttack, EffectValue: 4}, Combatant{Life: 10, Attack: 7, Defense: 2}},
+		{"defense", &Item{Name: "Shield", EffectType: EffectDefense, EffectValue: 4}, Combatant{Life: 10, Attack: 3, Defense: 6}},
+		{"life", &Item{Name: "Amulet", EffectType: EffectLife, EffectValue: 4}, Combatant{Life: 14, Attack: 3, Defense: 2}},
+		{"balance override", &Item{Name: "Cursed Sword", EffectType: EffectAttack, EffectValue: 9}, Combatant{Life: 10, Attack: 4, Defense: 2}},
+		{"unknown effect", &Item{Name: "Hat", EffectType: "style", EffectValue: 9}, Combatant{Life: 10, Attack: 3, Defense: 2}},
+	} {
+		c := base
+		c.Item = tt.item
+		b.Equip(&c)
+		c.Item = nil
+		if c != tt.want {
+			t.Errorf("%s: %+v, want %+v", tt.name, c, tt.want)
+		}
+	}
+}
+
+func TestHeal(t *testing.T) {
+	for _, tt := range []struct {
+		life, maxLife, value int
+		want                 int
+	}{
+		{5, 10, 3, 8},
+		{9, 10, 3, 10},
+		{10, 10, 3, 10},
+		{12, 10, 3, 12},
+	} {
+		c := Combatant{Life: tt.life, MaxLife: tt.maxLife}
+		c.Heal(tt.value)
+		if c.Life != tt.want {
+			t.Errorf("life %d of %d healed by %d: %d, want %d", tt.life, tt.maxLife, tt.value, c.Life, tt.want)
+		}
+	}
+}
@@ -0,0 +1,468 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+type Response struct {
+	Data   any      `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// A Query is one operation of a request, prepared to run with its variables.
+type Query struct {
+	schema    *Schema
+	doc       *document
+	op        *operation
+	variables map[string]any
+	errors    []*Error
+}
+
+// coerce converts an argument or variable value to the input type.
+func coerce(typeName string, value any) (any, error) {
+	if value == nil {
+		if strings.HasSuffix(typeName, "!") {
+			return nil, fmt.Errorf("expected a non-null %s", strings.TrimSuffix(typeName, "!"))
+		}
+		return nil, nil
+	}
+	typeName = strings.TrimSuffix(typeName, "!")
+	if isList(typeName) {
+		list, ok := value.([]any)
+		if !ok {
+			list = []any{value}
+		}
+		result := make([]any, len(list))
+		for i, item := range list {
+			coerced, err := coerce(typeName[1:len(typeName)-1], item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = coerced
+		}
+		return result, nil
+	}
+	switch typeName {
+	case "Int":
+		switch n := value.(type) {
+		case int64:
+			return n, nil
+		case float64:
+			if n == float64(int64(n)) {
+				return int64(n), nil
+			}
+		}
+	case "Float":
+		switch n := value.(type) {
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		}
+	case "String", "ID":
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		if n, ok := value.(int64); ok && typeName == "ID" {
+			return strconv.FormatInt(n, 10), nil
+		}
+	case "Boolean":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown input type %s", typeName)
+	}
+	return nil, fmt.Errorf("expected a %s, got %v", typeName, value)
+}
+
+// resolveValue replaces the variables in an argument value.
+func (q *Query) resolveValue(value any) any {
+	switch v := value.(type) {
+	case variable:
+		return q.variables[string(v)]
+	case enum:
+		return string(v)
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = q.resolveValue(item)
+		}
+		return result
+	case map[string]any:
+		result := map[string]any{}
+		for name, item := range v {
+			result[name] = q.resolveValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+func (q *Query) arguments(field *Field, s selection) (map[string]any, error) {
+	for name := range s.Args {
+		found := false
+		for _, arg := range field.Args {
+			found = found || arg.Name == name
+		}
+		if !found {
+			return nil, errorAt(s.Line, s.Col, "Unknown argument %q on field %q", name, field.Name)
+		}
+	}
+	args := map[string]any{}
+	for _, arg := range field.Args {
+		raw, ok := s.Args[arg.Name]
+		if ref, isVariable := raw.(variable); isVariable {
+			_, ok = q.variables[string(ref)]
+		}
+		value := arg.Default
+		if ok {
+			value = q.resolveValue(raw)
+		}
+		coerced, err := coerce(arg.Type, value)
+		if err != nil {
+			return nil, errorAt(s.Line, s.Col, "Argument %q of field %q: %v", arg.Name, field.Name, err)
+		}
+		args[arg.Name] = coerced
+	}
+	for _, name := range []string{"limit", "last"} {
+		if n, ok := args[name].(int64); ok && (n < 0 || n > MaxLimit) {
+			return nil, errorAt(s.Line, s.Col, "Argument %q of field %q must be between 0 and %d", name, field.Name, MaxLimit)
+		}
+	}
+	return args, nil
+}
+
+// included evaluates the @skip and @include directives.
+func (q *Query) included(s selection) (bool, error) {
+	for _, d := range s.Directives {
+		if d.Name != "skip" && d.Name != "include" {
+			return false, errorAt(s.Line, s.Col, "Unknown directive @%s", d.Name)
+		}
+		value, err := coerce("Boolean!", q.resolveValue(d.Args["if"]))
+		if err != nil {
+			return false, errorAt(s.Line, s.Col, "Argument \"if\" of @%s: %v", d.Name, err)
+		}
+		if value.(bool) == (d.Name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// collect flattens the fragments of a selection set on typeName into its
+// fields, grouped by response key in order of appearance. visiting holds the
+// fragments being spread, to reject cycles, and spread the fragments already
+// collected, as spreading one again adds nothing but would multiply the work
+// of a chain of fragments each spreading the next one twice.
+func (q *Query) collect(typeName string, selections []selection, fields *[]selection, visiting, spread map[string]bool) error {
+	for _, s := range selections {
+		ok, err := q.included(s)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		switch {
+		case s.Fragment != "":
+			fragment, ok := q.doc.Fragments[s.Fragment]
+			if !ok {
+				return errorAt(s.Line, s.Col, "Unknown fragment %q", s.Fragment)
+			}
+			if visiting[s.Fragment] {
+				return errorAt(s.Line, s.Col, "Fragment %q spreads itself", s.Fragment)
+			}
+			if spread[s.Fragment] {
+				continue
+			}
+			if err := q.checkTypeCondition(fragment.TypeCondition, typeName, s); err != nil {
+				return err
+			}
+			visiting[s.Fragment], spread[s.Fragment] = true, true
+			err := q.collect(typeName, fragment.Selections, fields, visiting, spread)
+			delete(visiting, s.Fragment)
+			if err != nil {
+				return err
+			}
+		case s.Inline:
+			if err := q.checkTypeCondition(s.TypeCondition, typeName, s); err != nil {
+				return err
+			}
+			if err := q.collect(typeName, s.Selections, fields, visiting, spread); err != nil {
+				return err
+			}
+		default:
+			merged := false
+			for i := range *fields {
+				if (*fields)[i].key() == s.key() {
+					if (*fields)[i].Name != s.Name {
+						return errorAt(s.Line, s.Col, "Fields %q conflict because they select different fields", s.key())
+					}
+					(*fields)[i].Selections = append((*fields)[i].Selections, s.Selections...)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				*fields = append(*fields, s)
+			}
+		}
+	}
+	return nil
+}
+
+// checkTypeCondition only accepts fragments on the type they are spread in,
+// as the schema has no interfaces or unions.
+func (q *Query) checkTypeCondition(condition, typeName string, s selection) error {
+	if condition == "" || condition == typeName {
+		return nil
+	}
+	if _, ok := q.schema.types[condition]; !ok {
+		return errorAt(s.Line, s.Col, "Unknown type %q", condition)
+	}
+	return errorAt(s.Line, s.Col, "Fragment on %q cannot be spread on %q", condition, typeName)
+}
+
+// Cost validates the query against the schema and returns its complexity,
+// each field counting one and the fields below a list counting once per
+// element it can return, and its depth. The error is an *Error.
+func (q *Query) Cost() (complexity, depth int, err error) {
+	return q.cost("Query", q.op.Selections)
+}
+
+// Execute runs a query whose Cost was measured without error. Errors of the
+// resolvers are reported next to the data.
+func (q *Query) Execute() Response {
+	q.errors = nil
+	response := Response{Data: q.execute("Query", nil, q.op.Selections, nil)}
+	response.Errors = q.errors
+	return response
+}
+
+// cost validates and measures a selection set on typeName.
+func (q *Query) cost(typeName string, selections []selection) (complexity, depth int, err error) {
+	var fields []selection
+	if err := q.collect(typeName, selections, &fields, map[string]bool{}, map[string]bool{}); err != nil {
+		return 0, 0, err
+	}
+	t := q.schema.types[typeName]
+	for _, s := range fields {
+		if s.Name == "__typename" {
+			complexity++
+			depth = max(depth, 1)
+			continue
+		}
+		field := t.field(s.Name)
+		if field == nil {
+			return 0, 0, errorAt(s.Line, s.Col, "Cannot query field %q on type %q", s.Name, typeName)
+		}
+		args, err := q.arguments(field, s)
+		if err != nil {
+			return 0, 0, err
+		}
+		named := namedType(field.Type)
+		if scalar(named) {
+			if s.Selections != nil {
+				return 0, 0, errorAt(s.Line, s.Col, "Field %q of type %q must not have a selection", s.Name, field.Type)
+			}
+			complexity++
+			depth = max(depth, 1)
+			continue
+		}
+		if s.Selections == nil {
+			return 0, 0, errorAt(s.Line, s.Col, "Field %q of type %q must have a selection of subfields", s.Name, field.Type)
+		}
+		childComplexity, childDepth, err := q.cost(named, s.Selections)
+		if err != nil {
+			return 0, 0, err
+		}
+		elements := 1
+		if isList(field.Type) {
+			for _, name := range []string{"limit", "last"} {
+				if n, ok := args[name].(int64); ok {
+					elements = int(n)
+				}
+			}
+		}
+		complexity += 1 + elements*childComplexity
+		depth = max(depth, 1+childDepth)
+	}
+	return complexity, depth, nil
+}
+
+// object keeps the fields of a result in the order they were selected.
+type object struct {
+	keys   []string
+	values []any
+}
+
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf strings.Builder
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		value, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return []byte(buf.String()), nil
+}
+
+// jsonField reads the struct field tagged with the JSON name.
+func jsonField(parent any, name string) any {
+	v := reflect.ValueOf(parent)
+	for i := 0; i < v.NumField(); i++ {
+		tag, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		if tag == name {
+			return v.Field(i).Interface()
+		}
+	}
+	return nil
+}
+
+func (q *Query) execute(typeName string, parent any, selections []selection, path []any) *object {
+	var fields []selection
+	q.collect(typeName, selections, &fields, map[string]bool{}, map[string]bool{})
+	result := &object{}
+	t := q.schema.types[typeName]
+	for _, s := range fields {
+		result.keys = append(result.keys, s.key())
+		if s.Name == "__typename" {
+			result.values = append(result.values, typeName)
+			continue
+		}
+		field := t.field(s.Name)
+		args, _ := q.arguments(field, s)
+		fieldPath := append(path[:len(path):len(path)], s.key())
+		var value any
+		var err error
+		if field.Resolve != nil {
+			value, err = field.Resolve(parent, args)
+		} else {
+			value = jsonField(parent, field.Name)
+		}
+		if err != nil {
+			q.errors = append(q.errors, &Error{Message: err.Error(), Locations: []Location{{s.Line, s.Col}}, Path: fieldPath})
+			value = nil
+		}
+		result.values = append(result.values, q.complete(field.Type, value, s.Selections, fieldPath))
+	}
+	return result
+}
+
+func (q *Query) complete(typeName string, value any, selections []selection, path []any) any {
+	if value == nil {
+		return nil
+	}
+	typeName = strings.TrimSuffix(typeName, "!")
+	if isList(typeName) {
+		list := value.([]any)
+		result := make([]any, len(list))
+		for i, item := range list {
+			result[i] = q.complete(typeName[1:len(typeName)-1], item, selections, append(path[:len(path):len(path)], i))
+		}
+		return result
+	}
+	if scalar(typeName) {
+		return value
+	}
+	return q.execute(typeName, value, selections, path)
+}
+
+// Prepare parses the request, picking the operation to run and coercing its
+// variables. The error is an *Error.
+func (s *Schema) Prepare(request Request) (*Query, error) {
+	doc, err := parse(request.Query)
+	if err != nil {
+		return nil, err
+	}
+	q := &Query{schema: s, doc: doc, variables: map[string]any{}}
+	for i := range doc.Operations {
+		op := &doc.Operations[i]
+		if request.OperationName == "" && len(doc.Operations) > 1 {
+			return nil, &Error{Message: "operationName is required when the query has several operations"}
+		}
+		if request.OperationName == "" || op.Name == request.OperationName {
+			q.op = op
+		}
+	}
+	if q.op == nil {
+		return nil, &Error{Message: fmt.Sprintf("Unknown operation %q", request.OperationName)}
+	}
+	if q.op.Type != "query" {
+		return nil, errorAt(q.op.Line, q.op.Col, "Only queries are supported, not %ss", q.op.Type)
+	}
+	for name, def := range q.op.Variables {
+		value, ok := request.Variables[name]
+		if !ok && def.HasDefault {
+			value, ok = q.resolveValue(def.Default), true
+		}
+		coerced, err := coerce(def.Type, value)
+		if err != nil {
+			return nil, errorAt(q.op.Line, q.op.Col, "Variable $%s: %v", name, err)
+		}
+		if ok {
+			q.variables[name] = coerced
+		}
+	}
+	if err := q.checkVariables(q.op.Selections, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// checkVariables rejects references to variables the operation does not
+// define.
+func (q *Query) checkVariables(selections []selection, visited map[string]bool) error {
+	for _, s := range selections {
+		values := []any{s.Args}
+		for _, d := range s.Directives {
+			values = append(values, d.Args)
+		}
+		for len(values) > 0 {
+			value := values[len(values)-1]
+			values = values[:len(values)-1]
+			switch v := value.(type) {
+			case variable:
+				if _, ok := q.op.Variables[string(v)]; !ok {
+					return errorAt(s.Line, s.Col, "Variable $%s is not defined", v)
+				}
+			case []any:
+				values = append(values, v...)
+			case map[string]any:
+				for _, item := range v {
+					values = append(values, item)
+				}
+			}
+		}
+		if s.Fragment != "" && !visited[s.Fragment] {
+			visited[s.Fragment] = true
+			if err := q.checkVariables(q.doc.Fragments[s.Fragment].Selections, visited); err != nil {
+				return err
+			}
+		}
+		if err := q.checkVariables(s.Selections, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
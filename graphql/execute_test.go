@@ -0,0 +1,185 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type hero struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+var heroes = []hero{{"Ayla", 3}, {"Bram", 5}, {"Cid", 1}}
+
+func heroList(n int64) []any {
+	result := []any{}
+	for _, h := range heroes[:min(int(n), len(heroes))] {
+		result = append(result, h)
+	}
+	return result
+}
+
+var testSchema = NewSchema(
+	&Type{Name: "Query", Fields: []Field{
+		{Name: "heroes", Type: "[Hero!]", Args: []Argument{{Name: "limit", Type: "Int!", Default: int64(2)}},
+			Resolve: func(_ any, args map[string]any) (any, error) { return heroList(args["limit"].(int64)), nil }},
+		{Name: "hero", Type: "Hero", Args: []Argument{{Name: "name", Type: "String!"}},
+			Resolve: func(_ any, args map[string]any) (any, error) {
+				for _, h := range heroes {
+					if h.Name == args["name"] {
+						return h, nil
+					}
+				}
+				return nil, nil
+			}},
+		{Name: "broken", Type: "Int", Resolve: func(any, map[string]any) (any, error) { return nil, errors.New("out of order") }},
+	}},
+	&Type{Name: "Hero", Fields: []Field{
+		{Name: "name", Type: "String!"},
+		{Name: "level", Type: "Int!"},
+		{Name: "friends", Type: "[Hero!]", Args: []Argument{{Name: "last", Type: "Int!", Default: int64(1)}},
+			Resolve: func(_ any, args map[string]any) (any, error) { return heroList(args["last"].(int64)), nil }},
+	}},
+)
+
+// run prepares, measures and executes the request, returning the response
+// as JSON.
+func run(t *testing.T, request Request) (string, error) {
+	t.Helper()
+	q, err := testSchema.Prepare(request)
+	if err != nil {
+		return "", err
+	}
+	if _, _, err := q.Cost(); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(q.Execute())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data), nil
+}
+
+func TestExecute(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		request Request
+		want    string
+	}{
+		{"fields in selection order", Request{Query: `{ heroes { level name } }`},
+			`{"data":{"heroes":[{"level":3,"name":"Ayla"},{"level":5,"name":"Bram"}]}}`},
+		{"aliases and arguments", Request{Query: `{ a: hero(name: "Ayla") { name } c: hero(name: "Cid") { level } nobody: hero(name: "Zed") { name } }`},
+			`{"data":{"a":{"name":"Ayla"},"c":{"level":1},"nobody":null}}`},
+		{"variables and defaults", Request{Query: `query($n: Int! = 3, $who: String!) { heroes(limit: $n) { name } hero(name: $who) { friends { name } } }`, Variables: map[string]any{"who": "Bram"}},
+			`{"data":{"heroes":[{"name":"Ayla"},{"name":"Bram"},{"name":"Cid"}],"hero":{"friends":[{"name":"Ayla"}]}}}`},
+		{"JSON numbers as Int variables", Request{Query: `query($n: Int!) { heroes(limit: $n) { name } }`, Variables: map[string]any{"n": 1.0}},
+			`{"data":{"heroes":[{"name":"Ayla"}]}}`},
+		{"fragments merged", Request{Query: `{ heroes(limit: 1) { ...N ... on Hero { level name } } } fragment N on Hero { name }`},
+			`{"data":{"heroes":[{"name":"Ayla","level":3}]}}`},
+		{"directives", Request{Query: `query($no: Boolean!) { heroes(limit: 1) { name @include(if: $no) level @skip(if: false) } }`, Variables: map[string]any{"no": false}},
+			`{"data":{"heroes":[{"level":3}]}}`},
+		{"typename", Request{Query: `{ __typename hero(name: "Cid") { __typename } }`},
+			`{"data":{"__typename":"Query","hero":{"__typename":"Hero"}}}`},
+		{"resolver errors next to the data", Request{Query: `{ broken heroes(limit: 1) { name } }`},
+			`{"data":{"broken":null,"heroes":[{"name":"Ayla"}]},"errors":[{"message":"out of order","locations":[{"line":1,"column":3}],"path":["broken"]}]}`},
+		{"named operation", Request{Query: `query A { broken } query B { hero(name: "Cid") { name } }`, OperationName: "B"},
+			`{"data":{"hero":{"name":"Cid"}}}`},
+	} {
+		got, err := run(t, tt.request)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		} else if got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInvalidQueries(t *testing.T) {
+	for _, tt := range []struct {
+		request Request
+		message string
+	}{
+		{Request{Query: `{ villains { name } }`}, `Cannot query field "villains" on type "Query"`},
+		{Request{Query: `{ heroes }`}, `Field "heroes" of type "[Hero!]" must have a selection of subfields`},
+		{Request{Query: `{ heroes { name { first } } }`}, `Field "name" of type "String!" must not have a selection`},
+		{Request{Query: `{ hero { name } }`}, `Argument "name" of field "hero": expected a non-null String`},
+		{Request{Query: `{ hero(name: 1) { name } }`}, `Argument "name" of field "hero": expected a String, got 1`},
+		{Request{Query: `{ heroes(power: 1) { name } }`}, `Unknown argument "power" on field "heroes"`},
+		{Request{Query: `{ heroes(limit: null) { name } }`}, `Argument "limit" of field "heroes": expected a non-null Int`},
+		{Request{Query: `{ heroes { friends(last: null) { name } } }`}, `Argument "last" of field "friends": expected a non-null Int`},
+		{Request{Query: `{ heroes(limit: 101) { name } }`}, `Argument "limit" of field "heroes" must be between 0 and 100`},
+		{Request{Query: `{ heroes { friends(last: -1) { name } } }`}, `Argument "last" of field "friends" must be between 0 and 100`},
+		{Request{Query: `query($n: Int!) { heroes(limit: $n) { name } }`}, `Variable $n: expected a non-null Int`},
+		{Request{Query: `{ heroes(limit: $n) { name } }`}, `Variable $n is not defined`},
+		{Request{Query: `{ heroes { ...F } } fragment F on Hero { ...F }`}, `Fragment "F" spreads itself`},
+		{Request{Query: `{ heroes { ...F } }`}, `Unknown fragment "F"`},
+		{Request{Query: `{ ...F } fragment F on Hero { name }`}, `Fragment on "Hero" cannot be spread on "Query"`},
+		{Request{Query: `{ heroes { name: level name } }`}, `Fields "name" conflict because they select different fields`},
+		{Request{Query: `{ heroes { name @shout } }`}, `Unknown directive @shout`},
+		{Request{Query: `mutation { heroes { name } }`}, `Only queries are supported, not mutations`},
+		{Request{Query: `query A { broken } query B { broken }`}, `operationName is required when the query has several operations`},
+		{Request{Query: `query A { broken }`, OperationName: "B"}, `Unknown operation "B"`},
+	} {
+		_, err := run(t, tt.request)
+		var gqlErr *Error
+		if !errors.As(err, &gqlErr) || gqlErr.Message != tt.message {
+			t.Errorf("%s: error %v, want %q", tt.request.Query, err, tt.message)
+		}
+	}
+}
+
+func TestCost(t *testing.T) {
+	for _, tt := range []struct {
+		query             string
+		complexity, depth int
+	}{
+		{`{ broken }`, 1, 1},
+		{`{ hero(name: "Ayla") { name level } }`, 3, 2},
+		// A list counts its subfields once per element it can return.
+		{`{ heroes { name } }`, 1 + 2*1, 2},
+		{`{ heroes(limit: 10) { name friends(last: 3) { name level } } }`, 1 + 10*(1+1+3*2), 3},
+		{`query($n: Int!) { heroes(limit: $n) { name } }`, 1 + 7*1, 2},
+		{`{ heroes(limit: 0) { name } }`, 1, 2},
+	} {
+		q, err := testSchema.Prepare(Request{Query: tt.query, Variables: map[string]any{"n": int64(7)}})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.query, err)
+		}
+		complexity, depth, err := q.Cost()
+		if err != nil || complexity != tt.complexity || depth != tt.depth {
+			t.Errorf("%s: complexity %d, depth %d, %v; want %d, %d", tt.query, complexity, depth, err, tt.complexity, tt.depth)
+		}
+	}
+}
+
+func TestSchemaString(t *testing.T) {
+	sdl := testSchema.String()
+	for _, want := range []string{
+		"type Query {\n  heroes(limit: Int! = 2): [Hero!]\n",
+		"  hero(name: String!): Hero\n",
+		"type Hero {\n  name: String!\n  level: Int!\n  friends(last: Int! = 1): [Hero!]\n}\n",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("schema misses %q:\n%s", want, sdl)
+		}
+	}
+}
+
+func TestRepeatedFragmentsAreCollectedOnce(t *testing.T) {
+	// Each fragment spreads the next one twice, which would be collected
+	// 2^40 times if repeated spreads were not skipped.
+	var query strings.Builder
+	query.WriteString(`{ heroes { ...F0 } }`)
+	for i := 0; i < 40; i++ {
+		fmt.Fprintf(&query, " fragment F%d on Hero { ...F%d ...F%d }", i, i+1, i+1)
+	}
+	query.WriteString(" fragment F40 on Hero { name }")
+	got, err := run(t, Request{Query: query.String()})
+	if want := `{"data":{"heroes":[{"name":"Ayla"},{"name":"Bram"}]}}`; err != nil || got != want {
+		t.Errorf("got %s, %v; want %s", got, err, want)
+	}
+}
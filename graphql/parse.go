@@ -0,0 +1,579 @@
+// Package graphql implements the subset of GraphQL a read-only API needs:
+// queries with arguments, variables, aliases, fragments and the @skip and
+// @include directives. Queries are checked against a Schema, and their cost
+// measured, before they run.
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits on the queries parsed, checked before the schema limits so a
+// large or deeply nested query is rejected without using much memory or
+// stack.
+const (
+	MaxQueryLength = 32 << 10 // bytes
+	MaxTokens      = 4096
+	// MaxNesting bounds the nesting of selection sets, list and object
+	// values, and list types.
+	MaxNesting = 32
+)
+
+// An Error is reported in the errors of the response, with the location
+// in the query or the path in the data it refers to.
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+	Path      []any      `json:"path,omitempty"`
+}
+
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func errorAt(line, col int, format string, args ...any) *Error {
+	return &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{{line, col}}}
+}
+
+type token struct {
+	kind  byte // 'n' name, 'i' int, 'f' float, 's' string, 'p' punctuator, 0 end
+	value string
+	line  int
+	col   int
+}
+
+func lex(source string) ([]token, error) {
+	var tokens []token
+	line, lineStart := 1, 0
+	for i := 0; i < len(source); {
+		c := source[i]
+		col := i - lineStart + 1
+		switch {
+		case c == '\n':
+			line, lineStart = line+1, i+1
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+		case len(tokens) >= MaxTokens:
+			return nil, errorAt(line, col, "Query has more than the maximum of %d tokens", MaxTokens)
+		case strings.HasPrefix(source[i:], "..."):
+			tokens = append(tokens, token{'p', "...", line, col})
+			i += 3
+		case strings.IndexByte("!$():=@[]{}|", c) >= 0:
+			tokens = append(tokens, token{'p', string(c), line, col})
+			i++
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			start := i
+			for i < len(source) && (source[i] == '_' || source[i] >= 'a' && source[i] <= 'z' || source[i] >= 'A' && source[i] <= 'Z' || source[i] >= '0' && source[i] <= '9') {
+				i++
+			}
+			tokens = append(tokens, token{'n', source[start:i], line, col})
+		case c == '-' || c >= '0' && c <= '9':
+			start, kind := i, byte('i')
+			i++
+			for i < len(source) && (source[i] >= '0' && source[i] <= '9' || strings.IndexByte(".eE+-", source[i]) >= 0) {
+				if strings.IndexByte(".eE", source[i]) >= 0 {
+					kind = 'f'
+				}
+				i++
+			}
+			tokens = append(tokens, token{kind, source[start:i], line, col})
+		case c == '"':
+			var value strings.Builder
+			i++
+			for {
+				if i >= len(source) || source[i] == '\n' {
+					return nil, errorAt(line, col, "Unterminated string")
+				}
+				if source[i] == '"' {
+					i++
+					break
+				}
+				if source[i] == '\\' && i+1 < len(source) {
+					escape := source[i+1]
+					switch escape {
+					case 'n':
+						value.WriteByte('\n')
+					case 't':
+						value.WriteByte('\t')
+					case 'r':
+						value.WriteByte('\r')
+					case 'b':
+						value.WriteByte('\b')
+					case 'f':
+						value.WriteByte('\f')
+					case 'u':
+						if i+6 > len(source) {
+							return nil, errorAt(line, col, "Invalid unicode escape")
+						}
+						code, err := strconv.ParseUint(source[i+2:i+6], 16, 32)
+						if err != nil {
+							return nil, errorAt(line, col, "Invalid unicode escape")
+						}
+						value.WriteRune(rune(code))
+						i += 4
+					default:
+						value.WriteByte(escape)
+					}
+					i += 2
+					continue
+				}
+				r, size := utf8.DecodeRuneInString(source[i:])
+				value.WriteRune(r)
+				i += size
+			}
+			tokens = append(tokens, token{'s', value.String(), line, col})
+		default:
+			return nil, errorAt(line, col, "Unexpected character %q", c)
+		}
+	}
+	return append(tokens, token{kind: 0, line: line, col: len(source) - lineStart + 1}), nil
+}
+
+// Values of arguments are parsed to Go values, with variable for variable
+// references and enum for enum values.
+type variable string
+type enum string
+
+type directive struct {
+	Name string
+	Args map[string]any
+}
+
+// A selection is a field, a fragment spread when Fragment is set, or an
+// inline fragment when Inline is set.
+type selection struct {
+	Alias         string
+	Name          string
+	Args          map[string]any
+	Directives    []directive
+	Selections    []selection
+	Fragment      string
+	Inline        bool
+	TypeCondition string
+	Line, Col     int
+}
+
+func (s selection) key() string {
+	if s.Alias != "" {
+		return s.Alias
+	}
+	return s.Name
+}
+
+type variableDef struct {
+	Type       string
+	Default    any
+	HasDefault bool
+}
+
+type operation struct {
+	Type       string
+	Name       string
+	Variables  map[string]variableDef
+	Selections []selection
+	Line, Col  int
+}
+
+type fragment struct {
+	TypeCondition string
+	Selections    []selection
+}
+
+type document struct {
+	Operations []operation
+	Fragments  map[string]fragment
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// enter starts a nested selection set, value or type, failing when the
+// nesting is too deep. Every successful enter must be followed by leave.
+func (p *parser) enter() error {
+	if p.depth++; p.depth > MaxNesting {
+		t := p.peek()
+		return errorAt(t.line, t.col, "Query nesting exceeds the maximum of %d", MaxNesting)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != 0 {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) is(kind byte, value string) bool {
+	t := p.peek()
+	return t.kind == kind && t.value == value
+}
+
+func (p *parser) unexpected() error {
+	t := p.peek()
+	if t.kind == 0 {
+		return errorAt(t.line, t.col, "Unexpected end of query")
+	}
+	return errorAt(t.line, t.col, "Unexpected %q", t.value)
+}
+
+func (p *parser) expect(value string) error {
+	if !p.is('p', value) {
+		return p.unexpected()
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) name() (string, error) {
+	if p.peek().kind != 'n' {
+		return "", p.unexpected()
+	}
+	return p.next().value, nil
+}
+
+func parse(source string) (*document, error) {
+	if len(source) > MaxQueryLength {
+		return nil, &Error{Message: fmt.Sprintf("Query is longer than the maximum of %d bytes", MaxQueryLength)}
+	}
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	doc := &document{Fragments: map[string]fragment{}}
+	for p.peek().kind != 0 {
+		t := p.peek()
+		switch {
+		case t.kind == 'p' && t.value == "{":
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, operation{Type: "query", Selections: selections, Line: t.line, Col: t.col})
+		case t.kind == 'n' && (t.value == "query" || t.value == "mutation" || t.value == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case t.kind == 'n' && t.value == "fragment":
+			p.next()
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if !p.is('n', "on") {
+				return nil, p.unexpected()
+			}
+			p.next()
+			typeCondition, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.directives(true); err != nil {
+				return nil, err
+			}
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[name]; ok {
+				return nil, errorAt(t.line, t.col, "There can be only one fragment named %q", name)
+			}
+			doc.Fragments[name] = fragment{TypeCondition: typeCondition, Selections: selections}
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, &Error{Message: "The query has no operation"}
+	}
+	return doc, nil
+}
+
+func (p *parser) operation() (operation, error) {
+	t := p.next()
+	op := operation{Type: t.value, Variables: map[string]variableDef{}, Line: t.line, Col: t.col}
+	if p.peek().kind == 'n' {
+		op.Name = p.next().value
+	}
+	if p.is('p', "(") {
+		p.next()
+		for !p.is('p', ")") {
+			if err := p.expect("$"); err != nil {
+				return op, err
+			}
+			name, err := p.name()
+			if err != nil {
+				return op, err
+			}
+			if err := p.expect(":"); err != nil {
+				return op, err
+			}
+			var def variableDef
+			if def.Type, err = p.typeRef(); err != nil {
+				return op, err
+			}
+			if p.is('p', "=") {
+				p.next()
+				if def.Default, err = p.value(true); err != nil {
+					return op, err
+				}
+				def.HasDefault = true
+			}
+			op.Variables[name] = def
+		}
+		p.next()
+	}
+	if _, err := p.directives(true); err != nil {
+		return op, err
+	}
+	var err error
+	op.Selections, err = p.selectionSet()
+	return op, err
+}
+
+func (p *parser) typeRef() (string, error) {
+	var typeName string
+	if p.is('p', "[") {
+		if err := p.enter(); err != nil {
+			return "", err
+		}
+		defer p.leave()
+		p.next()
+		inner, err := p.typeRef()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		typeName = "[" + inner + "]"
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return "", err
+		}
+		typeName = name
+	}
+	if p.is('p', "!") {
+		p.next()
+		typeName += "!"
+	}
+	return typeName, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []selection
+	for !p.is('p', "}") {
+		selection, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	p.next()
+	if len(selections) == 0 {
+		t := p.tokens[p.pos-1]
+		return nil, errorAt(t.line, t.col, "Empty selection set")
+	}
+	return selections, nil
+}
+
+func (p *parser) selection() (selection, error) {
+	t := p.peek()
+	s := selection{Line: t.line, Col: t.col}
+	var err error
+	if p.is('p', "...") {
+		p.next()
+		if p.peek().kind == 'n' && p.peek().value != "on" {
+			s.Fragment = p.next().value
+			s.Directives, err = p.directives(false)
+			return s, err
+		}
+		s.Inline = true
+		if p.is('n', "on") {
+			p.next()
+			if s.TypeCondition, err = p.name(); err != nil {
+				return s, err
+			}
+		}
+		if s.Directives, err = p.directives(false); err != nil {
+			return s, err
+		}
+		s.Selections, err = p.selectionSet()
+		return s, err
+	}
+	if s.Name, err = p.name(); err != nil {
+		return s, err
+	}
+	if p.is('p', ":") {
+		p.next()
+		s.Alias = s.Name
+		if s.Name, err = p.name(); err != nil {
+			return s, err
+		}
+	}
+	if s.Args, err = p.arguments(false); err != nil {
+		return s, err
+	}
+	if s.Directives, err = p.directives(false); err != nil {
+		return s, err
+	}
+	if p.is('p', "{") {
+		s.Selections, err = p.selectionSet()
+	}
+	return s, err
+}
+
+func (p *parser) arguments(constant bool) (map[string]any, error) {
+	args := map[string]any{}
+	if !p.is('p', "(") {
+		return args, nil
+	}
+	p.next()
+	for !p.is('p', ")") {
+		t := p.peek()
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if _, ok := args[name]; ok {
+			return nil, errorAt(t.line, t.col, "There can be only one argument named %q", name)
+		}
+		if args[name], err = p.value(constant); err != nil {
+			return nil, err
+		}
+	}
+	p.next()
+	return args, nil
+}
+
+func (p *parser) directives(constant bool) ([]directive, error) {
+	var directives []directive
+	for p.is('p', "@") {
+		p.next()
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.arguments(constant)
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, directive{Name: name, Args: args})
+	}
+	return directives, nil
+}
+
+func (p *parser) value(constant bool) (any, error) {
+	t := p.peek()
+	switch {
+	case t.kind == 'p' && t.value == "$" && !constant:
+		p.next()
+		name, err := p.name()
+		return variable(name), err
+	case t.kind == 'i':
+		p.next()
+		n, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return nil, errorAt(t.line, t.col, "Invalid number %s", t.value)
+		}
+		return n, nil
+	case t.kind == 'f':
+		p.next()
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, errorAt(t.line, t.col, "Invalid number %s", t.value)
+		}
+		return f, nil
+	case t.kind == 's':
+		p.next()
+		return t.value, nil
+	case t.kind == 'n':
+		p.next()
+		switch t.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return enum(t.value), nil
+	case t.kind == 'p' && t.value == "[":
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		p.next()
+		list := []any{}
+		for !p.is('p', "]") {
+			value, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		p.next()
+		return list, nil
+	case t.kind == 'p' && t.value == "{":
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		p.next()
+		object := map[string]any{}
+		for !p.is('p', "}") {
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if object[name], err = p.value(constant); err != nil {
+				return nil, err
+			}
+		}
+		p.next()
+		return object, nil
+	}
+	return nil, p.unexpected()
+}
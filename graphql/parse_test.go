@@ -0,0 +1,135 @@
+package graphql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := parse(`
+		# The heroes and their friends.
+		query Heroes($limit: Int! = 2, $skip: Boolean) {
+			first: heroes(limit: $limit, tag: "a\"b") @skip(if: $skip) {
+				...Names
+				... on Hero { level }
+			}
+		}
+		fragment Names on Hero { name }
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := doc.Operations[0]
+	if op.Type != "query" || op.Name != "Heroes" {
+		t.Errorf("operation %s %s, want query Heroes", op.Type, op.Name)
+	}
+	if def := op.Variables["limit"]; def.Type != "Int!" || def.Default != int64(2) || !def.HasDefault {
+		t.Errorf("$limit %+v", def)
+	}
+	if def := op.Variables["skip"]; def.Type != "Boolean" || def.HasDefault {
+		t.Errorf("$skip %+v", def)
+	}
+
+	s := op.Selections[0]
+	if s.Alias != "first" || s.Name != "heroes" || s.key() != "first" {
+		t.Errorf("field %q aliased %q", s.Name, s.Alias)
+	}
+	if want := map[string]any{"limit": variable("limit"), "tag": `a"b`}; !reflect.DeepEqual(s.Args, want) {
+		t.Errorf("arguments %#v, want %#v", s.Args, want)
+	}
+	if len(s.Directives) != 1 || s.Directives[0].Name != "skip" || s.Directives[0].Args["if"] != variable("skip") {
+		t.Errorf("directives %+v", s.Directives)
+	}
+	if s.Selections[0].Fragment != "Names" || !s.Selections[1].Inline || s.Selections[1].TypeCondition != "Hero" {
+		t.Errorf("fragments %+v", s.Selections)
+	}
+	if fragment := doc.Fragments["Names"]; fragment.TypeCondition != "Hero" || fragment.Selections[0].Name != "name" {
+		t.Errorf("fragment %+v", fragment)
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	doc, err := parse(`{ f(i: -3, f: 1.5e1, s: "é", b: true, n: null, e: RED, l: [1, 2], o: {a: 1}) }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"i": int64(-3),
+		"f": 15.0,
+		"s": "é",
+		"b": true,
+		"n": nil,
+		"e": enum("RED"),
+		"l": []any{int64(1), int64(2)},
+		"o": map[string]any{"a": int64(1)},
+	}
+	if got := doc.Operations[0].Selections[0].Args; !reflect.DeepEqual(got, want) {
+		t.Errorf("arguments %#v, want %#v", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct {
+		query   string
+		message string
+		line    int
+		column  int
+	}{
+		{"", "The query has no operation", 0, 0},
+		{"{", "Unexpected end of query", 1, 2},
+		{"{ }", "Empty selection set", 1, 3},
+		{"{\n  a(x: 1, x: 2)\n}", `There can be only one argument named "x"`, 2, 11},
+		{`{ a(s: "open) }`, "Unterminated string", 1, 8},
+		{"{ a(x: $v) }\nfragment F on Hero { a }\nfragment F on Hero { b }", `There can be only one fragment named "F"`, 3, 1},
+		{"{ a } }", `Unexpected "}"`, 1, 7},
+		{"{ a(x: %) }", `Unexpected character '%'`, 1, 8},
+		{"query($v: Int = $w) { a }", `Unexpected "$"`, 1, 17},
+		{"{ a }" + strings.Repeat(" ", MaxQueryLength), "Query is longer than the maximum of 32768 bytes", 0, 0},
+		{"{" + strings.Repeat(" a", MaxTokens) + " }", "Query has more than the maximum of 4096 tokens", 1, 8193},
+		{strings.Repeat("{ a ", MaxNesting+1), "Query nesting exceeds the maximum of 32", 1, 129},
+		{"{ a(l: " + strings.Repeat("[", MaxNesting+1) + ") }", "Query nesting exceeds the maximum of 32", 1, 39},
+		{"query($v: " + strings.Repeat("[", MaxNesting+1) + "Int) { a }", "Query nesting exceeds the maximum of 32", 1, 43},
+	} {
+		_, err := parse(tt.query)
+		gqlErr, ok := err.(*Error)
+		if !ok {
+			t.Errorf("%q: error %v, want %q", tt.query, err, tt.message)
+			continue
+		}
+		if gqlErr.Message != tt.message {
+			t.Errorf("%q: %q, want %q", tt.query, gqlErr.Message, tt.message)
+		}
+		if tt.line != 0 && (len(gqlErr.Locations) != 1 || gqlErr.Locations[0] != Location{tt.line, tt.column}) {
+			t.Errorf("%q: locations %v, want %d:%d", tt.query, gqlErr.Locations, tt.line, tt.column)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, query := range []string{
+		`{ heroes { name level } }`,
+		`query Heroes($limit: Int! = 2, $skip: Boolean) { first: heroes(limit: $limit) @skip(if: $skip) { ...Names ... on Hero { level } } } fragment Names on Hero { name }`,
+		`{ f(i: -3, f: 1.5e1, s: "\u00e9\n", b: true, n: null, e: RED, l: [1, 2], o: {a: 1}) }`,
+		`{ a(s: "open) }`,
+		"# comment\n{ a }",
+	} {
+		f.Add(query)
+	}
+	f.Fuzz(func(t *testing.T, query string) {
+		doc, err := parse(query)
+		if err != nil {
+			if _, ok := err.(*Error); !ok {
+				t.Fatalf("error %T %v, want an *Error", err, err)
+			}
+			return
+		}
+		if len(doc.Operations) == 0 {
+			t.Fatal("document without operations parsed")
+		}
+		// Whatever parses must be checked and measured without panicking.
+		if q, err := testSchema.Prepare(Request{Query: query}); err == nil {
+			q.Cost()
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxLimit bounds the limit and last arguments, which also give the number
+// of elements a list field is expected to return when measuring the cost of
+// a query.
+const MaxLimit = 100
+
+// Resolver returns the value of a field of parent. Objects are returned
+// as the Go records and lists as []any.
+type Resolver func(parent any, args map[string]any) (any, error)
+
+// An Argument of a field. Its Default is used when the query leaves it out,
+// and must be of the Go type the argument is coerced to: int64 for Int,
+// float64 for Float, string for String and ID and bool for Boolean.
+type Argument struct {
+	Name    string
+	Type    string
+	Default any
+}
+
+// A Field of a Type. Type is written as in the schema language, "Player",
+// "[Battle!]" or "Int!".
+type Field struct {
+	Name string
+	Type string
+	Args []Argument
+	// Resolve defaults to reading the struct field with the same JSON name.
+	Resolve Resolver
+}
+
+// A Type is an object type of the schema.
+type Type struct {
+	Name   string
+	Fields []Field
+}
+
+func (t *Type) field(name string) *Field {
+	for i := range t.Fields {
+		if t.Fields[i].Name == name {
+			return &t.Fields[i]
+		}
+	}
+	return nil
+}
+
+// A Schema is the set of types a query can select from, starting at the
+// type named Query.
+type Schema struct {
+	list  []*Type
+	types map[string]*Type
+}
+
+func NewSchema(types ...*Type) *Schema {
+	s := &Schema{list: types, types: map[string]*Type{}}
+	for _, t := range types {
+		s.types[t.Name] = t
+	}
+	return s
+}
+
+// String returns the schema in the GraphQL schema language.
+func (s *Schema) String() string {
+	var sdl strings.Builder
+	for i, t := range s.list {
+		if i > 0 {
+			sdl.WriteString("\n")
+		}
+		fmt.Fprintf(&sdl, "type %s {\n", t.Name)
+		for _, f := range t.Fields {
+			sdl.WriteString("  " + f.Name)
+			if len(f.Args) > 0 {
+				args := make([]string, len(f.Args))
+				for i, a := range f.Args {
+					args[i] = a.Name + ": " + a.Type
+					if a.Default != nil {
+						args[i] += fmt.Sprintf(" = %v", a.Default)
+					}
+				}
+				sdl.WriteString("(" + strings.Join(args, ", ") + ")")
+			}
+			sdl.WriteString(": " + f.Type + "\n")
+		}
+		sdl.WriteString("}\n")
+	}
+	return sdl.String()
+}
+
+// namedType strips the list and non-null wrappers of a type.
+func namedType(typeName string) string {
+	return strings.Trim(typeName, "[]!")
+}
+
+func isList(typeName string) bool {
+	return strings.HasPrefix(typeName, "[")
+}
+
+func scalar(name string) bool {
+	switch name {
+	case "String", "Int", "Float", "Boolean", "ID":
+		return true
+	}
+	return false
+}
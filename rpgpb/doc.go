@@ -0,0 +1,4 @@
+// Package rpgpb holds the protobuf messages and gRPC services of the API.
+package rpgpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative rpg.proto
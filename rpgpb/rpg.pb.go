@@ -0,0 +1,1312 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: rpg.proto
+
+package rpgpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Player struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nickname      string                 `protobuf:"bytes,1,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Life          int32                  `protobuf:"varint,2,opt,name=life,proto3" json:"life,omitempty"`
+	Attack        int32                  `protobuf:"varint,3,opt,name=attack,proto3" json:"attack,omitempty"`
+	Defense       int32                  `protobuf:"varint,4,opt,name=defense,proto3" json:"defense,omitempty"`
+	Speed         int32                  `protobuf:"varint,5,opt,name=speed,proto3" json:"speed,omitempty"`
+	ItemId        string                 `protobuf:"bytes,6,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Owner         string                 `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	mi := &file_rpg_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Player) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *Player) GetLife() int32 {
+	if x != nil {
+		return x.Life
+	}
+	return 0
+}
+
+func (x *Player) GetAttack() int32 {
+	if x != nil {
+		return x.Attack
+	}
+	return 0
+}
+
+func (x *Player) GetDefense() int32 {
+	if x != nil {
+		return x.Defense
+	}
+	return 0
+}
+
+func (x *Player) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *Player) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *Player) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type Enemy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nickname      string                 `protobuf:"bytes,1,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Life          int32                  `protobuf:"varint,2,opt,name=life,proto3" json:"life,omitempty"`
+	Attack        int32                  `protobuf:"varint,3,opt,name=attack,proto3" json:"attack,omitempty"`
+	Defense       int32                  `protobuf:"varint,4,opt,name=defense,proto3" json:"defense,omitempty"`
+	Speed         int32                  `protobuf:"varint,5,opt,name=speed,proto3" json:"speed,omitempty"`
+	ItemId        string                 `protobuf:"bytes,6,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Behavior      string                 `protobuf:"bytes,7,opt,name=behavior,proto3" json:"behavior,omitempty"`
+	Template      string                 `protobuf:"bytes,8,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Enemy) Reset() {
+	*x = Enemy{}
+	mi := &file_rpg_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Enemy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Enemy) ProtoMessage() {}
+
+func (x *Enemy) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Enemy.ProtoReflect.Descriptor instead.
+func (*Enemy) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Enemy) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *Enemy) GetLife() int32 {
+	if x != nil {
+		return x.Life
+	}
+	return 0
+}
+
+func (x *Enemy) GetAttack() int32 {
+	if x != nil {
+		return x.Attack
+	}
+	return 0
+}
+
+func (x *Enemy) GetDefense() int32 {
+	if x != nil {
+		return x.Defense
+	}
+	return 0
+}
+
+func (x *Enemy) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *Enemy) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *Enemy) GetBehavior() string {
+	if x != nil {
+		return x.Behavior
+	}
+	return ""
+}
+
+func (x *Enemy) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+type Item struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	EffectType    string                 `protobuf:"bytes,3,opt,name=effect_type,json=effectType,proto3" json:"effect_type,omitempty"`
+	EffectValue   int32                  `protobuf:"varint,4,opt,name=effect_value,json=effectValue,proto3" json:"effect_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_rpg_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Item) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Item) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Item) GetEffectType() string {
+	if x != nil {
+		return x.EffectType
+	}
+	return ""
+}
+
+func (x *Item) GetEffectValue() int32 {
+	if x != nil {
+		return x.EffectValue
+	}
+	return 0
+}
+
+type Battle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Round         int32                  `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Enemy         string                 `protobuf:"bytes,4,opt,name=enemy,proto3" json:"enemy,omitempty"`
+	Player        string                 `protobuf:"bytes,5,opt,name=player,proto3" json:"player,omitempty"`
+	DiceThrown    int32                  `protobuf:"varint,6,opt,name=dice_thrown,json=diceThrown,proto3" json:"dice_thrown,omitempty"`
+	Action        string                 `protobuf:"bytes,7,opt,name=action,proto3" json:"action,omitempty"`
+	EnemyAction   string                 `protobuf:"bytes,8,opt,name=enemy_action,json=enemyAction,proto3" json:"enemy_action,omitempty"`
+	PlayerDamage  int32                  `protobuf:"varint,9,opt,name=player_damage,json=playerDamage,proto3" json:"player_damage,omitempty"`
+	EnemyDamage   int32                  `protobuf:"varint,10,opt,name=enemy_damage,json=enemyDamage,proto3" json:"enemy_damage,omitempty"`
+	Outcome       string                 `protobuf:"bytes,11,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Battle) Reset() {
+	*x = Battle{}
+	mi := &file_rpg_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Battle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Battle) ProtoMessage() {}
+
+func (x *Battle) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Battle.ProtoReflect.Descriptor instead.
+func (*Battle) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Battle) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Battle) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Battle) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *Battle) GetEnemy() string {
+	if x != nil {
+		return x.Enemy
+	}
+	return ""
+}
+
+func (x *Battle) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *Battle) GetDiceThrown() int32 {
+	if x != nil {
+		return x.DiceThrown
+	}
+	return 0
+}
+
+func (x *Battle) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Battle) GetEnemyAction() string {
+	if x != nil {
+		return x.EnemyAction
+	}
+	return ""
+}
+
+func (x *Battle) GetPlayerDamage() int32 {
+	if x != nil {
+		return x.PlayerDamage
+	}
+	return 0
+}
+
+func (x *Battle) GetEnemyDamage() int32 {
+	if x != nil {
+		return x.EnemyDamage
+	}
+	return 0
+}
+
+func (x *Battle) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *Battle) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	SessionId     string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Player        string                 `protobuf:"bytes,4,opt,name=player,proto3" json:"player,omitempty"`
+	Enemy         string                 `protobuf:"bytes,5,opt,name=enemy,proto3" json:"enemy,omitempty"`
+	Battle        *Battle                `protobuf:"bytes,6,opt,name=battle,proto3" json:"battle,omitempty"`
+	Item          *Item                  `protobuf:"bytes,7,opt,name=item,proto3" json:"item,omitempty"`
+	Target        string                 `protobuf:"bytes,8,opt,name=target,proto3" json:"target,omitempty"`
+	Outcome       string                 `protobuf:"bytes,9,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_rpg_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Event) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *Event) GetEnemy() string {
+	if x != nil {
+		return x.Enemy
+	}
+	return ""
+}
+
+func (x *Event) GetBattle() *Battle {
+	if x != nil {
+		return x.Battle
+	}
+	return nil
+}
+
+func (x *Event) GetItem() *Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *Event) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Event) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *Event) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ListRequest takes the same pagination, sorting and filters as the query
+// parameters of the HTTP list endpoints.
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Filters       map[string]string      `protobuf:"bytes,4,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_rpg_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListRequest) GetFilters() map[string]string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+type PlayerList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Players       []*Player              `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerList) Reset() {
+	*x = PlayerList{}
+	mi := &file_rpg_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerList) ProtoMessage() {}
+
+func (x *PlayerList) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerList.ProtoReflect.Descriptor instead.
+func (*PlayerList) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PlayerList) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *PlayerList) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type EnemyList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enemies       []*Enemy               `protobuf:"bytes,1,rep,name=enemies,proto3" json:"enemies,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnemyList) Reset() {
+	*x = EnemyList{}
+	mi := &file_rpg_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnemyList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnemyList) ProtoMessage() {}
+
+func (x *EnemyList) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnemyList.ProtoReflect.Descriptor instead.
+func (*EnemyList) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EnemyList) GetEnemies() []*Enemy {
+	if x != nil {
+		return x.Enemies
+	}
+	return nil
+}
+
+func (x *EnemyList) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ItemList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemList) Reset() {
+	*x = ItemList{}
+	mi := &file_rpg_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemList) ProtoMessage() {}
+
+func (x *ItemList) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemList.ProtoReflect.Descriptor instead.
+func (*ItemList) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ItemList) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ItemList) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type BattleList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Battles       []*Battle              `protobuf:"bytes,1,rep,name=battles,proto3" json:"battles,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BattleList) Reset() {
+	*x = BattleList{}
+	mi := &file_rpg_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleList) ProtoMessage() {}
+
+func (x *BattleList) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleList.ProtoReflect.Descriptor instead.
+func (*BattleList) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BattleList) GetBattles() []*Battle {
+	if x != nil {
+		return x.Battles
+	}
+	return nil
+}
+
+func (x *BattleList) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type NicknameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nickname      string                 `protobuf:"bytes,1,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NicknameRequest) Reset() {
+	*x = NicknameRequest{}
+	mi := &file_rpg_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NicknameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NicknameRequest) ProtoMessage() {}
+
+func (x *NicknameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NicknameRequest.ProtoReflect.Descriptor instead.
+func (*NicknameRequest) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NicknameRequest) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+type SavePlayerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nickname      string                 `protobuf:"bytes,1,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Player        *Player                `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavePlayerRequest) Reset() {
+	*x = SavePlayerRequest{}
+	mi := &file_rpg_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavePlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavePlayerRequest) ProtoMessage() {}
+
+func (x *SavePlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavePlayerRequest.ProtoReflect.Descriptor instead.
+func (*SavePlayerRequest) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SavePlayerRequest) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *SavePlayerRequest) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+type UpdateEnemyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nickname      string                 `protobuf:"bytes,1,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Enemy         *Enemy                 `protobuf:"bytes,2,opt,name=enemy,proto3" json:"enemy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEnemyRequest) Reset() {
+	*x = UpdateEnemyRequest{}
+	mi := &file_rpg_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEnemyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEnemyRequest) ProtoMessage() {}
+
+func (x *UpdateEnemyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEnemyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEnemyRequest) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateEnemyRequest) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *UpdateEnemyRequest) GetEnemy() *Enemy {
+	if x != nil {
+		return x.Enemy
+	}
+	return nil
+}
+
+type BattleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Enemy         string                 `protobuf:"bytes,2,opt,name=enemy,proto3" json:"enemy,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BattleRequest) Reset() {
+	*x = BattleRequest{}
+	mi := &file_rpg_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleRequest) ProtoMessage() {}
+
+func (x *BattleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleRequest.ProtoReflect.Descriptor instead.
+func (*BattleRequest) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BattleRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *BattleRequest) GetEnemy() string {
+	if x != nil {
+		return x.Enemy
+	}
+	return ""
+}
+
+func (x *BattleRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Player        string                 `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	Enemy         string                 `protobuf:"bytes,3,opt,name=enemy,proto3" json:"enemy,omitempty"`
+	LastEventId   int64                  `protobuf:"varint,4,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_rpg_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpg_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_rpg_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StreamEventsRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetEnemy() string {
+	if x != nil {
+		return x.Enemy
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetLastEventId() int64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+var File_rpg_proto protoreflect.FileDescriptor
+
+const file_rpg_proto_rawDesc = "" +
+	"\n" +
+	"\trpg.proto\x12\x06rpg.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaf\x01\n" +
+	"\x06Player\x12\x1a\n" +
+	"\bnickname\x18\x01 \x01(\tR\bnickname\x12\x12\n" +
+	"\x04life\x18\x02 \x01(\x05R\x04life\x12\x16\n" +
+	"\x06attack\x18\x03 \x01(\x05R\x06attack\x12\x18\n" +
+	"\adefense\x18\x04 \x01(\x05R\adefense\x12\x14\n" +
+	"\x05speed\x18\x05 \x01(\x05R\x05speed\x12\x17\n" +
+	"\aitem_id\x18\x06 \x01(\tR\x06itemId\x12\x14\n" +
+	"\x05owner\x18\a \x01(\tR\x05owner\"\xd0\x01\n" +
+	"\x05Enemy\x12\x1a\n" +
+	"\bnickname\x18\x01 \x01(\tR\bnickname\x12\x12\n" +
+	"\x04life\x18\x02 \x01(\x05R\x04life\x12\x16\n" +
+	"\x06attack\x18\x03 \x01(\x05R\x06attack\x12\x18\n" +
+	"\adefense\x18\x04 \x01(\x05R\adefense\x12\x14\n" +
+	"\x05speed\x18\x05 \x01(\x05R\x05speed\x12\x17\n" +
+	"\aitem_id\x18\x06 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bbehavior\x18\a \x01(\tR\bbehavior\x12\x1a\n" +
+	"\btemplate\x18\b \x01(\tR\btemplate\"n\n" +
+	"\x04Item\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\veffect_type\x18\x03 \x01(\tR\n" +
+	"effectType\x12!\n" +
+	"\feffect_value\x18\x04 \x01(\x05R\veffectValue\"\xf4\x02\n" +
+	"\x06Battle\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x14\n" +
+	"\x05round\x18\x03 \x01(\x05R\x05round\x12\x14\n" +
+	"\x05enemy\x18\x04 \x01(\tR\x05enemy\x12\x16\n" +
+	"\x06player\x18\x05 \x01(\tR\x06player\x12\x1f\n" +
+	"\vdice_thrown\x18\x06 \x01(\x05R\n" +
+	"diceThrown\x12\x16\n" +
+	"\x06action\x18\a \x01(\tR\x06action\x12!\n" +
+	"\fenemy_action\x18\b \x01(\tR\venemyAction\x12#\n" +
+	"\rplayer_damage\x18\t \x01(\x05R\fplayerDamage\x12!\n" +
+	"\fenemy_damage\x18\n" +
+	" \x01(\x05R\venemyDamage\x12\x18\n" +
+	"\aoutcome\x18\v \x01(\tR\aoutcome\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xaf\x02\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06player\x18\x04 \x01(\tR\x06player\x12\x14\n" +
+	"\x05enemy\x18\x05 \x01(\tR\x05enemy\x12&\n" +
+	"\x06battle\x18\x06 \x01(\v2\x0e.rpg.v1.BattleR\x06battle\x12 \n" +
+	"\x04item\x18\a \x01(\v2\f.rpg.v1.ItemR\x04item\x12\x16\n" +
+	"\x06target\x18\b \x01(\tR\x06target\x12\x18\n" +
+	"\aoutcome\x18\t \x01(\tR\aoutcome\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc7\x01\n" +
+	"\vListRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12:\n" +
+	"\afilters\x18\x04 \x03(\v2 .rpg.v1.ListRequest.FiltersEntryR\afilters\x1a:\n" +
+	"\fFiltersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"L\n" +
+	"\n" +
+	"PlayerList\x12(\n" +
+	"\aplayers\x18\x01 \x03(\v2\x0e.rpg.v1.PlayerR\aplayers\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"J\n" +
+	"\tEnemyList\x12'\n" +
+	"\aenemies\x18\x01 \x03(\v2\r.rpg.v1.EnemyR\aenemies\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"D\n" +
+	"\bItemList\x12\"\n" +
+	"\x05items\x18\x01 \x03(\v2\f.rpg.v1.ItemR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"L\n" +
+	"\n" +
+	"BattleList\x12(\n" +
+	"\abattles\x18\x01 \x03(\v2\x0e.rpg.v1.BattleR\abattles\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"-\n" +
+	"\x0fNicknameRequest\x12\x1a\n" +
+	"\bnickname\x18\x01 \x01(\tR\bnickname\"W\n" +
+	"\x11SavePlayerRequest\x12\x1a\n" +
+	"\bnickname\x18\x01 \x01(\tR\bnickname\x12&\n" +
+	"\x06player\x18\x02 \x01(\v2\x0e.rpg.v1.PlayerR\x06player\"U\n" +
+	"\x12UpdateEnemyRequest\x12\x1a\n" +
+	"\bnickname\x18\x01 \x01(\tR\bnickname\x12#\n" +
+	"\x05enemy\x18\x02 \x01(\v2\r.rpg.v1.EnemyR\x05enemy\"U\n" +
+	"\rBattleRequest\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\x14\n" +
+	"\x05enemy\x18\x02 \x01(\tR\x05enemy\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\"\x86\x01\n" +
+	"\x13StreamEventsRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06player\x18\x02 \x01(\tR\x06player\x12\x14\n" +
+	"\x05enemy\x18\x03 \x01(\tR\x05enemy\x12\"\n" +
+	"\rlast_event_id\x18\x04 \x01(\x03R\vlastEventId2\xaf\x02\n" +
+	"\rPlayerService\x12+\n" +
+	"\tAddPlayer\x12\x0e.rpg.v1.Player\x1a\x0e.rpg.v1.Player\x126\n" +
+	"\vLoadPlayers\x12\x13.rpg.v1.ListRequest\x1a\x12.rpg.v1.PlayerList\x12?\n" +
+	"\x14LoadPlayerByNickname\x12\x17.rpg.v1.NicknameRequest\x1a\x0e.rpg.v1.Player\x127\n" +
+	"\n" +
+	"SavePlayer\x12\x19.rpg.v1.SavePlayerRequest\x1a\x0e.rpg.v1.Player\x12?\n" +
+	"\fDeletePlayer\x12\x17.rpg.v1.NicknameRequest\x1a\x16.google.protobuf.Empty2\xa8\x02\n" +
+	"\fEnemyService\x12(\n" +
+	"\bAddEnemy\x12\r.rpg.v1.Enemy\x1a\r.rpg.v1.Enemy\x125\n" +
+	"\vLoadEnemies\x12\x13.rpg.v1.ListRequest\x1a\x11.rpg.v1.EnemyList\x12=\n" +
+	"\x13LoadEnemyByNickname\x12\x17.rpg.v1.NicknameRequest\x1a\r.rpg.v1.Enemy\x128\n" +
+	"\vUpdateEnemy\x12\x1a.rpg.v1.UpdateEnemyRequest\x1a\r.rpg.v1.Enemy\x12>\n" +
+	"\vDeleteEnemy\x12\x17.rpg.v1.NicknameRequest\x1a\x16.google.protobuf.Empty2h\n" +
+	"\vItemService\x12%\n" +
+	"\aAddItem\x12\f.rpg.v1.Item\x1a\f.rpg.v1.Item\x122\n" +
+	"\tLoadItems\x12\x13.rpg.v1.ListRequest\x1a\x10.rpg.v1.ItemList2\xc2\x01\n" +
+	"\rBattleService\x125\n" +
+	"\fCreateBattle\x12\x15.rpg.v1.BattleRequest\x1a\x0e.rpg.v1.Battle\x126\n" +
+	"\vLoadBattles\x12\x13.rpg.v1.ListRequest\x1a\x12.rpg.v1.BattleList\x12B\n" +
+	"\x12StreamBattleEvents\x12\x1b.rpg.v1.StreamEventsRequest\x1a\r.rpg.v1.Event0\x01B-Z+github.com/Uemerson/go-simple-rpg-api/rpgpbb\x06proto3"
+
+var (
+	file_rpg_proto_rawDescOnce sync.Once
+	file_rpg_proto_rawDescData []byte
+)
+
+func file_rpg_proto_rawDescGZIP() []byte {
+	file_rpg_proto_rawDescOnce.Do(func() {
+		file_rpg_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpg_proto_rawDesc), len(file_rpg_proto_rawDesc)))
+	})
+	return file_rpg_proto_rawDescData
+}
+
+var file_rpg_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_rpg_proto_goTypes = []any{
+	(*Player)(nil),                // 0: rpg.v1.Player
+	(*Enemy)(nil),                 // 1: rpg.v1.Enemy
+	(*Item)(nil),                  // 2: rpg.v1.Item
+	(*Battle)(nil),                // 3: rpg.v1.Battle
+	(*Event)(nil),                 // 4: rpg.v1.Event
+	(*ListRequest)(nil),           // 5: rpg.v1.ListRequest
+	(*PlayerList)(nil),            // 6: rpg.v1.PlayerList
+	(*EnemyList)(nil),             // 7: rpg.v1.EnemyList
+	(*ItemList)(nil),              // 8: rpg.v1.ItemList
+	(*BattleList)(nil),            // 9: rpg.v1.BattleList
+	(*NicknameRequest)(nil),       // 10: rpg.v1.NicknameRequest
+	(*SavePlayerRequest)(nil),     // 11: rpg.v1.SavePlayerRequest
+	(*UpdateEnemyRequest)(nil),    // 12: rpg.v1.UpdateEnemyRequest
+	(*BattleRequest)(nil),         // 13: rpg.v1.BattleRequest
+	(*StreamEventsRequest)(nil),   // 14: rpg.v1.StreamEventsRequest
+	nil,                           // 15: rpg.v1.ListRequest.FiltersEntry
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 17: google.protobuf.Empty
+}
+var file_rpg_proto_depIdxs = []int32{
+	16, // 0: rpg.v1.Battle.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 1: rpg.v1.Event.battle:type_name -> rpg.v1.Battle
+	2,  // 2: rpg.v1.Event.item:type_name -> rpg.v1.Item
+	16, // 3: rpg.v1.Event.created_at:type_name -> google.protobuf.Timestamp
+	15, // 4: rpg.v1.ListRequest.filters:type_name -> rpg.v1.ListRequest.FiltersEntry
+	0,  // 5: rpg.v1.PlayerList.players:type_name -> rpg.v1.Player
+	1,  // 6: rpg.v1.EnemyList.enemies:type_name -> rpg.v1.Enemy
+	2,  // 7: rpg.v1.ItemList.items:type_name -> rpg.v1.Item
+	3,  // 8: rpg.v1.BattleList.battles:type_name -> rpg.v1.Battle
+	0,  // 9: rpg.v1.SavePlayerRequest.player:type_name -> rpg.v1.Player
+	1,  // 10: rpg.v1.UpdateEnemyRequest.enemy:type_name -> rpg.v1.Enemy
+	0,  // 11: rpg.v1.PlayerService.AddPlayer:input_type -> rpg.v1.Player
+	5,  // 12: rpg.v1.PlayerService.LoadPlayers:input_type -> rpg.v1.ListRequest
+	10, // 13: rpg.v1.PlayerService.LoadPlayerByNickname:input_type -> rpg.v1.NicknameRequest
+	11, // 14: rpg.v1.PlayerService.SavePlayer:input_type -> rpg.v1.SavePlayerRequest
+	10, // 15: rpg.v1.PlayerService.DeletePlayer:input_type -> rpg.v1.NicknameRequest
+	1,  // 16: rpg.v1.EnemyService.AddEnemy:input_type -> rpg.v1.Enemy
+	5,  // 17: rpg.v1.EnemyService.LoadEnemies:input_type -> rpg.v1.ListRequest
+	10, // 18: rpg.v1.EnemyService.LoadEnemyByNickname:input_type -> rpg.v1.NicknameRequest
+	12, // 19: rpg.v1.EnemyService.UpdateEnemy:input_type -> rpg.v1.UpdateEnemyRequest
+	10, // 20: rpg.v1.EnemyService.DeleteEnemy:input_type -> rpg.v1.NicknameRequest
+	2,  // 21: rpg.v1.ItemService.AddItem:input_type -> rpg.v1.Item
+	5,  // 22: rpg.v1.ItemService.LoadItems:input_type -> rpg.v1.ListRequest
+	13, // 23: rpg.v1.BattleService.CreateBattle:input_type -> rpg.v1.BattleRequest
+	5,  // 24: rpg.v1.BattleService.LoadBattles:input_type -> rpg.v1.ListRequest
+	14, // 25: rpg.v1.BattleService.StreamBattleEvents:input_type -> rpg.v1.StreamEventsRequest
+	0,  // 26: rpg.v1.PlayerService.AddPlayer:output_type -> rpg.v1.Player
+	6,  // 27: rpg.v1.PlayerService.LoadPlayers:output_type -> rpg.v1.PlayerList
+	0,  // 28: rpg.v1.PlayerService.LoadPlayerByNickname:output_type -> rpg.v1.Player
+	0,  // 29: rpg.v1.PlayerService.SavePlayer:output_type -> rpg.v1.Player
+	17, // 30: rpg.v1.PlayerService.DeletePlayer:output_type -> google.protobuf.Empty
+	1,  // 31: rpg.v1.EnemyService.AddEnemy:output_type -> rpg.v1.Enemy
+	7,  // 32: rpg.v1.EnemyService.LoadEnemies:output_type -> rpg.v1.EnemyList
+	1,  // 33: rpg.v1.EnemyService.LoadEnemyByNickname:output_type -> rpg.v1.Enemy
+	1,  // 34: rpg.v1.EnemyService.UpdateEnemy:output_type -> rpg.v1.Enemy
+	17, // 35: rpg.v1.EnemyService.DeleteEnemy:output_type -> google.protobuf.Empty
+	2,  // 36: rpg.v1.ItemService.AddItem:output_type -> rpg.v1.Item
+	8,  // 37: rpg.v1.ItemService.LoadItems:output_type -> rpg.v1.ItemList
+	3,  // 38: rpg.v1.BattleService.CreateBattle:output_type -> rpg.v1.Battle
+	9,  // 39: rpg.v1.BattleService.LoadBattles:output_type -> rpg.v1.BattleList
+	4,  // 40: rpg.v1.BattleService.StreamBattleEvents:output_type -> rpg.v1.Event
+	26, // [26:41] is the sub-list for method output_type
+	11, // [11:26] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_rpg_proto_init() }
+func file_rpg_proto_init() {
+	if File_rpg_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpg_proto_rawDesc), len(file_rpg_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_rpg_proto_goTypes,
+		DependencyIndexes: file_rpg_proto_depIdxs,
+		MessageInfos:      file_rpg_proto_msgTypes,
+	}.Build()
+	File_rpg_proto = out.File
+	file_rpg_proto_goTypes = nil
+	file_rpg_proto_depIdxs = nil
+}
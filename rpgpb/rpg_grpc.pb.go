@@ -0,0 +1,856 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rpg.proto
+
+package rpgpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PlayerService_AddPlayer_FullMethodName            = "/rpg.v1.PlayerService/AddPlayer"
+	PlayerService_LoadPlayers_FullMethodName          = "/rpg.v1.PlayerService/LoadPlayers"
+	PlayerService_LoadPlayerByNickname_FullMethodName = "/rpg.v1.PlayerService/LoadPlayerByNickname"
+	PlayerService_SavePlayer_FullMethodName           = "/rpg.v1.PlayerService/SavePlayer"
+	PlayerService_DeletePlayer_FullMethodName         = "/rpg.v1.PlayerService/DeletePlayer"
+)
+
+// PlayerServiceClient is the client API for PlayerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PlayerServiceClient interface {
+	AddPlayer(ctx context.Context, in *Player, opts ...grpc.CallOption) (*Player, error)
+	LoadPlayers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*PlayerList, error)
+	LoadPlayerByNickname(ctx context.Context, in *NicknameRequest, opts ...grpc.CallOption) (*Player, error)
+	SavePlayer(ctx context.Context, in *SavePlayerRequest, opts ...grpc.CallOption) (*Player, error)
+	DeletePlayer(ctx context.Context, in *NicknameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type playerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlayerServiceClient(cc grpc.ClientConnInterface) PlayerServiceClient {
+	return &playerServiceClient{cc}
+}
+
+func (c *playerServiceClient) AddPlayer(ctx context.Context, in *Player, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, PlayerService_AddPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerServiceClient) LoadPlayers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*PlayerList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayerList)
+	err := c.cc.Invoke(ctx, PlayerService_LoadPlayers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerServiceClient) LoadPlayerByNickname(ctx context.Context, in *NicknameRequest, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, PlayerService_LoadPlayerByNickname_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerServiceClient) SavePlayer(ctx context.Context, in *SavePlayerRequest, opts ...grpc.CallOption) (*Player, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Player)
+	err := c.cc.Invoke(ctx, PlayerService_SavePlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerServiceClient) DeletePlayer(ctx context.Context, in *NicknameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PlayerService_DeletePlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlayerServiceServer is the server API for PlayerService service.
+// All implementations must embed UnimplementedPlayerServiceServer
+// for forward compatibility.
+type PlayerServiceServer interface {
+	AddPlayer(context.Context, *Player) (*Player, error)
+	LoadPlayers(context.Context, *ListRequest) (*PlayerList, error)
+	LoadPlayerByNickname(context.Context, *NicknameRequest) (*Player, error)
+	SavePlayer(context.Context, *SavePlayerRequest) (*Player, error)
+	DeletePlayer(context.Context, *NicknameRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPlayerServiceServer()
+}
+
+// UnimplementedPlayerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPlayerServiceServer struct{}
+
+func (UnimplementedPlayerServiceServer) AddPlayer(context.Context, *Player) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPlayer not implemented")
+}
+func (UnimplementedPlayerServiceServer) LoadPlayers(context.Context, *ListRequest) (*PlayerList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadPlayers not implemented")
+}
+func (UnimplementedPlayerServiceServer) LoadPlayerByNickname(context.Context, *NicknameRequest) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadPlayerByNickname not implemented")
+}
+func (UnimplementedPlayerServiceServer) SavePlayer(context.Context, *SavePlayerRequest) (*Player, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SavePlayer not implemented")
+}
+func (UnimplementedPlayerServiceServer) DeletePlayer(context.Context, *NicknameRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePlayer not implemented")
+}
+func (UnimplementedPlayerServiceServer) mustEmbedUnimplementedPlayerServiceServer() {}
+func (UnimplementedPlayerServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafePlayerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlayerServiceServer will
+// result in compilation errors.
+type UnsafePlayerServiceServer interface {
+	mustEmbedUnimplementedPlayerServiceServer()
+}
+
+func RegisterPlayerServiceServer(s grpc.ServiceRegistrar, srv PlayerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPlayerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PlayerService_ServiceDesc, srv)
+}
+
+func _PlayerService_AddPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Player)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).AddPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_AddPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).AddPlayer(ctx, req.(*Player))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerService_LoadPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).LoadPlayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_LoadPlayers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).LoadPlayers(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerService_LoadPlayerByNickname_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NicknameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).LoadPlayerByNickname(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_LoadPlayerByNickname_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).LoadPlayerByNickname(ctx, req.(*NicknameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerService_SavePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SavePlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).SavePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_SavePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).SavePlayer(ctx, req.(*SavePlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerService_DeletePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NicknameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).DeletePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_DeletePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).DeletePlayer(ctx, req.(*NicknameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PlayerService_ServiceDesc is the grpc.ServiceDesc for PlayerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PlayerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rpg.v1.PlayerService",
+	HandlerType: (*PlayerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddPlayer",
+			Handler:    _PlayerService_AddPlayer_Handler,
+		},
+		{
+			MethodName: "LoadPlayers",
+			Handler:    _PlayerService_LoadPlayers_Handler,
+		},
+		{
+			MethodName: "LoadPlayerByNickname",
+			Handler:    _PlayerService_LoadPlayerByNickname_Handler,
+		},
+		{
+			MethodName: "SavePlayer",
+			Handler:    _PlayerService_SavePlayer_Handler,
+		},
+		{
+			MethodName: "DeletePlayer",
+			Handler:    _PlayerService_DeletePlayer_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpg.proto",
+}
+
+const (
+	EnemyService_AddEnemy_FullMethodName            = "/rpg.v1.EnemyService/AddEnemy"
+	EnemyService_LoadEnemies_FullMethodName         = "/rpg.v1.EnemyService/LoadEnemies"
+	EnemyService_LoadEnemyByNickname_FullMethodName = "/rpg.v1.EnemyService/LoadEnemyByNickname"
+	EnemyService_UpdateEnemy_FullMethodName         = "/rpg.v1.EnemyService/UpdateEnemy"
+	EnemyService_DeleteEnemy_FullMethodName         = "/rpg.v1.EnemyService/DeleteEnemy"
+)
+
+// EnemyServiceClient is the client API for EnemyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EnemyServiceClient interface {
+	AddEnemy(ctx context.Context, in *Enemy, opts ...grpc.CallOption) (*Enemy, error)
+	LoadEnemies(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*EnemyList, error)
+	LoadEnemyByNickname(ctx context.Context, in *NicknameRequest, opts ...grpc.CallOption) (*Enemy, error)
+	UpdateEnemy(ctx context.Context, in *UpdateEnemyRequest, opts ...grpc.CallOption) (*Enemy, error)
+	DeleteEnemy(ctx context.Context, in *NicknameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type enemyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEnemyServiceClient(cc grpc.ClientConnInterface) EnemyServiceClient {
+	return &enemyServiceClient{cc}
+}
+
+func (c *enemyServiceClient) AddEnemy(ctx context.Context, in *Enemy, opts ...grpc.CallOption) (*Enemy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Enemy)
+	err := c.cc.Invoke(ctx, EnemyService_AddEnemy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enemyServiceClient) LoadEnemies(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*EnemyList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnemyList)
+	err := c.cc.Invoke(ctx, EnemyService_LoadEnemies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enemyServiceClient) LoadEnemyByNickname(ctx context.Context, in *NicknameRequest, opts ...grpc.CallOption) (*Enemy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Enemy)
+	err := c.cc.Invoke(ctx, EnemyService_LoadEnemyByNickname_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enemyServiceClient) UpdateEnemy(ctx context.Context, in *UpdateEnemyRequest, opts ...grpc.CallOption) (*Enemy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Enemy)
+	err := c.cc.Invoke(ctx, EnemyService_UpdateEnemy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enemyServiceClient) DeleteEnemy(ctx context.Context, in *NicknameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, EnemyService_DeleteEnemy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnemyServiceServer is the server API for EnemyService service.
+// All implementations must embed UnimplementedEnemyServiceServer
+// for forward compatibility.
+type EnemyServiceServer interface {
+	AddEnemy(context.Context, *Enemy) (*Enemy, error)
+	LoadEnemies(context.Context, *ListRequest) (*EnemyList, error)
+	LoadEnemyByNickname(context.Context, *NicknameRequest) (*Enemy, error)
+	UpdateEnemy(context.Context, *UpdateEnemyRequest) (*Enemy, error)
+	DeleteEnemy(context.Context, *NicknameRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedEnemyServiceServer()
+}
+
+// UnimplementedEnemyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEnemyServiceServer struct{}
+
+func (UnimplementedEnemyServiceServer) AddEnemy(context.Context, *Enemy) (*Enemy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddEnemy not implemented")
+}
+func (UnimplementedEnemyServiceServer) LoadEnemies(context.Context, *ListRequest) (*EnemyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadEnemies not implemented")
+}
+func (UnimplementedEnemyServiceServer) LoadEnemyByNickname(context.Context, *NicknameRequest) (*Enemy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadEnemyByNickname not implemented")
+}
+func (UnimplementedEnemyServiceServer) UpdateEnemy(context.Context, *UpdateEnemyRequest) (*Enemy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEnemy not implemented")
+}
+func (UnimplementedEnemyServiceServer) DeleteEnemy(context.Context, *NicknameRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEnemy not implemented")
+}
+func (UnimplementedEnemyServiceServer) mustEmbedUnimplementedEnemyServiceServer() {}
+func (UnimplementedEnemyServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeEnemyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EnemyServiceServer will
+// result in compilation errors.
+type UnsafeEnemyServiceServer interface {
+	mustEmbedUnimplementedEnemyServiceServer()
+}
+
+func RegisterEnemyServiceServer(s grpc.ServiceRegistrar, srv EnemyServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEnemyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EnemyService_ServiceDesc, srv)
+}
+
+func _EnemyService_AddEnemy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Enemy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnemyServiceServer).AddEnemy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnemyService_AddEnemy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnemyServiceServer).AddEnemy(ctx, req.(*Enemy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnemyService_LoadEnemies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnemyServiceServer).LoadEnemies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnemyService_LoadEnemies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnemyServiceServer).LoadEnemies(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnemyService_LoadEnemyByNickname_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NicknameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnemyServiceServer).LoadEnemyByNickname(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnemyService_LoadEnemyByNickname_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnemyServiceServer).LoadEnemyByNickname(ctx, req.(*NicknameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnemyService_UpdateEnemy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEnemyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnemyServiceServer).UpdateEnemy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnemyService_UpdateEnemy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnemyServiceServer).UpdateEnemy(ctx, req.(*UpdateEnemyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnemyService_DeleteEnemy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NicknameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnemyServiceServer).DeleteEnemy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnemyService_DeleteEnemy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnemyServiceServer).DeleteEnemy(ctx, req.(*NicknameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EnemyService_ServiceDesc is the grpc.ServiceDesc for EnemyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EnemyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rpg.v1.EnemyService",
+	HandlerType: (*EnemyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddEnemy",
+			Handler:    _EnemyService_AddEnemy_Handler,
+		},
+		{
+			MethodName: "LoadEnemies",
+			Handler:    _EnemyService_LoadEnemies_Handler,
+		},
+		{
+			MethodName: "LoadEnemyByNickname",
+			Handler:    _EnemyService_LoadEnemyByNickname_Handler,
+		},
+		{
+			MethodName: "UpdateEnemy",
+			Handler:    _EnemyService_UpdateEnemy_Handler,
+		},
+		{
+			MethodName: "DeleteEnemy",
+			Handler:    _EnemyService_DeleteEnemy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpg.proto",
+}
+
+const (
+	ItemService_AddItem_FullMethodName   = "/rpg.v1.ItemService/AddItem"
+	ItemService_LoadItems_FullMethodName = "/rpg.v1.ItemService/LoadItems"
+)
+
+// ItemServiceClient is the client API for ItemService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ItemServiceClient interface {
+	AddItem(ctx context.Context, in *Item, opts ...grpc.CallOption) (*Item, error)
+	LoadItems(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ItemList, error)
+}
+
+type itemServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewItemServiceClient(cc grpc.ClientConnInterface) ItemServiceClient {
+	return &itemServiceClient{cc}
+}
+
+func (c *itemServiceClient) AddItem(ctx context.Context, in *Item, opts ...grpc.CallOption) (*Item, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Item)
+	err := c.cc.Invoke(ctx, ItemService_AddItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) LoadItems(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ItemList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ItemList)
+	err := c.cc.Invoke(ctx, ItemService_LoadItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ItemServiceServer is the server API for ItemService service.
+// All implementations must embed UnimplementedItemServiceServer
+// for forward compatibility.
+type ItemServiceServer interface {
+	AddItem(context.Context, *Item) (*Item, error)
+	LoadItems(context.Context, *ListRequest) (*ItemList, error)
+	mustEmbedUnimplementedItemServiceServer()
+}
+
+// UnimplementedItemServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedItemServiceServer struct{}
+
+func (UnimplementedItemServiceServer) AddItem(context.Context, *Item) (*Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItem not implemented")
+}
+func (UnimplementedItemServiceServer) LoadItems(context.Context, *ListRequest) (*ItemList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadItems not implemented")
+}
+func (UnimplementedItemServiceServer) mustEmbedUnimplementedItemServiceServer() {}
+func (UnimplementedItemServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeItemServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ItemServiceServer will
+// result in compilation errors.
+type UnsafeItemServiceServer interface {
+	mustEmbedUnimplementedItemServiceServer()
+}
+
+func RegisterItemServiceServer(s grpc.ServiceRegistrar, srv ItemServiceServer) {
+	// If the following call pancis, it indicates UnimplementedItemServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ItemService_ServiceDesc, srv)
+}
+
+func _ItemService_AddItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Item)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).AddItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_AddItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).AddItem(ctx, req.(*Item))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_LoadItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).LoadItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_LoadItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).LoadItems(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ItemService_ServiceDesc is the grpc.ServiceDesc for ItemService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ItemService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rpg.v1.ItemService",
+	HandlerType: (*ItemServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddItem",
+			Handler:    _ItemService_AddItem_Handler,
+		},
+		{
+			MethodName: "LoadItems",
+			Handler:    _ItemService_LoadItems_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpg.proto",
+}
+
+const (
+	BattleService_CreateBattle_FullMethodName       = "/rpg.v1.BattleService/CreateBattle"
+	BattleService_LoadBattles_FullMethodName        = "/rpg.v1.BattleService/LoadBattles"
+	BattleService_StreamBattleEvents_FullMethodName = "/rpg.v1.BattleService/StreamBattleEvents"
+)
+
+// BattleServiceClient is the client API for BattleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BattleServiceClient interface {
+	CreateBattle(ctx context.Context, in *BattleRequest, opts ...grpc.CallOption) (*Battle, error)
+	LoadBattles(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*BattleList, error)
+	// StreamBattleEvents streams the events of one battle session when
+	// session_id is set, and of every battle of the player and enemy
+	// otherwise. The events still kept after last_event_id are sent first.
+	StreamBattleEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type battleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBattleServiceClient(cc grpc.ClientConnInterface) BattleServiceClient {
+	return &battleServiceClient{cc}
+}
+
+func (c *battleServiceClient) CreateBattle(ctx context.Context, in *BattleRequest, opts ...grpc.CallOption) (*Battle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Battle)
+	err := c.cc.Invoke(ctx, BattleService_CreateBattle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleServiceClient) LoadBattles(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*BattleList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BattleList)
+	err := c.cc.Invoke(ctx, BattleService_LoadBattles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleServiceClient) StreamBattleEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BattleService_ServiceDesc.Streams[0], BattleService_StreamBattleEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BattleService_StreamBattleEventsClient = grpc.ServerStreamingClient[Event]
+
+// BattleServiceServer is the server API for BattleService service.
+// All implementations must embed UnimplementedBattleServiceServer
+// for forward compatibility.
+type BattleServiceServer interface {
+	CreateBattle(context.Context, *BattleRequest) (*Battle, error)
+	LoadBattles(context.Context, *ListRequest) (*BattleList, error)
+	// StreamBattleEvents streams the events of one battle session when
+	// session_id is set, and of every battle of the player and enemy
+	// otherwise. The events still kept after last_event_id are sent first.
+	StreamBattleEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedBattleServiceServer()
+}
+
+// UnimplementedBattleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBattleServiceServer struct{}
+
+func (UnimplementedBattleServiceServer) CreateBattle(context.Context, *BattleRequest) (*Battle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBattle not implemented")
+}
+func (UnimplementedBattleServiceServer) LoadBattles(context.Context, *ListRequest) (*BattleList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadBattles not implemented")
+}
+func (UnimplementedBattleServiceServer) StreamBattleEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBattleEvents not implemented")
+}
+func (UnimplementedBattleServiceServer) mustEmbedUnimplementedBattleServiceServer() {}
+func (UnimplementedBattleServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeBattleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BattleServiceServer will
+// result in compilation errors.
+type UnsafeBattleServiceServer interface {
+	mustEmbedUnimplementedBattleServiceServer()
+}
+
+func RegisterBattleServiceServer(s grpc.ServiceRegistrar, srv BattleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBattleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BattleService_ServiceDesc, srv)
+}
+
+func _BattleService_CreateBattle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BattleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleServiceServer).CreateBattle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleService_CreateBattle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleServiceServer).CreateBattle(ctx, req.(*BattleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BattleService_LoadBattles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleServiceServer).LoadBattles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleService_LoadBattles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleServiceServer).LoadBattles(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BattleService_StreamBattleEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BattleServiceServer).StreamBattleEvents(m, &grpc.GenericServerStream[StreamEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BattleService_StreamBattleEventsServer = grpc.ServerStreamingServer[Event]
+
+// BattleService_ServiceDesc is the grpc.ServiceDesc for BattleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BattleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rpg.v1.BattleService",
+	HandlerType: (*BattleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBattle",
+			Handler:    _BattleService_CreateBattle_Handler,
+		},
+		{
+			MethodName: "LoadBattles",
+			Handler:    _BattleService_LoadBattles_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBattleEvents",
+			Handler:       _BattleService_StreamBattleEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpg.proto",
+}